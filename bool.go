@@ -0,0 +1,139 @@
+/*
+--------------------------------
+@Create 2026/10/18 14:50
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 14:50
+@Description 布尔值词汇表
+--------------------------------
+本文件实现了可配置的布尔值词汇表，取代固定的真/假值列表。词汇表可以全局注册、
+在单次解码调用中指定，或通过字段的strval标签为单个字段指定；还可以配置"未知"词汇，
+配合NullBool实现三态值。JSON、YAML与数据库路径共用同一套解析逻辑。
+*/
+
+package strval
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// BoolVocabulary 布尔值词汇表，匹配时不区分大小写并去除前后空白
+type BoolVocabulary struct {
+	// True 解析为true的词汇
+	True []string
+	// False 解析为false的词汇
+	False []string
+	// Unknown 解析为未知值的词汇：Bool得到false且不记录错误，NullBool得到无效值
+	Unknown []string
+}
+
+var (
+	// DefaultBoolVocabulary 默认词汇表，与早期版本固定支持的值一致
+	DefaultBoolVocabulary = BoolVocabulary{
+		True:  []string{"true", "yes", "y", "1"},
+		False: []string{"false", "no", "n", "0"},
+	}
+
+	// ExtendedBoolVocabulary 在默认词汇表基础上增加常见遗留系统使用的写法
+	ExtendedBoolVocabulary = BoolVocabulary{
+		True:  []string{"true", "yes", "y", "1", "t", "on", "enabled", "enable", "active"},
+		False: []string{"false", "no", "n", "0", "f", "off", "disabled", "disable", "inactive"},
+	}
+)
+
+// lookup 在词汇表中查找已规范化（小写、去空白）的词
+// 返回值:
+//   - bool: 布尔值
+//   - bool: 是否为已知的真/假值，命中Unknown时为false
+//   - bool: 是否命中词汇表
+func (v *BoolVocabulary) lookup(s string) (value, valid, ok bool) {
+	switch {
+	case containsFold(v.True, s):
+		return true, true, true
+	case containsFold(v.False, s):
+		return false, true, true
+	case containsFold(v.Unknown, s):
+		return false, false, true
+	default:
+		return false, false, false
+	}
+}
+
+// merge 返回合并了另一词汇表的新词汇表
+func (v BoolVocabulary) merge(other BoolVocabulary) BoolVocabulary {
+	return BoolVocabulary{
+		True:    append(slices.Clip(v.True), other.True...),
+		False:   append(slices.Clip(v.False), other.False...),
+		Unknown: append(slices.Clip(v.Unknown), other.Unknown...),
+	}
+}
+
+// containsFold 判断列表中是否包含不区分大小写相等的词
+func containsFold(list []string, s string) bool {
+	for _, w := range list {
+		if strings.EqualFold(strings.TrimSpace(w), s) {
+			return true
+		}
+	}
+	return false
+}
+
+// boolVocabulary 获取选项中生效的词汇表
+func (o *Options) boolVocabulary() *BoolVocabulary {
+	if o.BoolVocabulary != nil {
+		return o.BoolVocabulary
+	}
+	return &DefaultBoolVocabulary
+}
+
+// RegisterBoolTokens 向全局词汇表注册额外的真/假值词汇
+// 参数:
+//   - trueTokens: 解析为true的词汇
+//   - falseTokens: 解析为false的词汇
+//
+// 说明：在当前全局词汇表（未配置时为DefaultBoolVocabulary）的基础上追加，通常在程序初始化时调用
+func RegisterBoolTokens(trueTokens, falseTokens []string) {
+	updateDefaultBoolVocabulary(BoolVocabulary{True: trueTokens, False: falseTokens})
+}
+
+// RegisterUnknownBoolTokens 向全局词汇表注册表示未知值的词汇，如"unknown"、"n/a"
+func RegisterUnknownBoolTokens(tokens ...string) {
+	updateDefaultBoolVocabulary(BoolVocabulary{Unknown: tokens})
+}
+
+// updateDefaultBoolVocabulary 以原子方式将词汇合并到全局选项
+func updateDefaultBoolVocabulary(add BoolVocabulary) {
+	updateDefaultOptions(func(o *Options) {
+		merged := o.boolVocabulary().merge(add)
+		o.BoolVocabulary = &merged
+	})
+}
+
+// parseTriBool 解析字符串形式的三态布尔值
+// 参数:
+//   - s: 输入字符串
+//   - o: 解析选项
+//
+// 返回值:
+//   - bool: 解析后的布尔值
+//   - bool: 是否为已知的真/假值，命中未知值词汇时为false
+//   - error: 解析过程中的错误
+//
+// 说明：先匹配Options.BoolVocabulary，启用UnicodeChineseBools时再匹配中文布尔词
+func parseTriBool(s string, o *Options) (bool, bool, error) {
+	if o.Unicode&UnicodeFullWidth != 0 {
+		s = foldWidth(s)
+	}
+	s = strings.ToLower(strings.TrimSpace(s))
+	if v, valid, ok := o.boolVocabulary().lookup(s); ok {
+		return v, valid, nil
+	}
+	if o.Unicode&UnicodeChineseBools != 0 {
+		if v, ok := parseChineseBool(s); ok {
+			return v, true, nil
+		}
+	}
+	return false, false, fmt.Errorf("cannot parse '%s' as bool", s)
+}
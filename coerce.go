@@ -0,0 +1,307 @@
+/*
+--------------------------------
+@Create 2026/10/19 01:40
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 01:40
+@Description 各格式共用的类型转换核心
+--------------------------------
+UnmarshalJSON、UnmarshalYAML、UnmarshalText与Scan原先各自实现"先尝试原生类型，再尝试字符串"的逻辑，
+各份实现逐渐产生差异，例如Scan接受整数形式的Bool而JSON不接受。本文件将转换规则集中到一处：
+1. 各格式的适配器只负责把输入识别为格式无关的标量（null、布尔值、整数、浮点数、字符串或无效值）
+2. 各类型的coerce方法按统一的规则将标量转换为目标值，字符串统一交给setString解析
+
+统一后的规则：
+  - null得到零值，NullBool得到无效值
+  - Bool与NullBool接受布尔值、字符串以及整数0与1，整数不经过词汇表，以便自定义词汇表时仍能读取TINYINT(1)等列
+  - Int接受整数、小数部分为零且在范围内的浮点数与字符串
+  - Float接受整数、浮点数与字符串，NaN/±Inf按NonFiniteInput处理
+  - String接受任意标量；JSON与YAML中的数值和布尔值保留输入中的写法（如1.50），数据库中的值按最短形式格式化
+  - 数据库返回的时间：Int与Float取Unix时间戳（秒），String按RFC 3339格式化，Bool与NullBool不接受
+
+Scan在交给coerce之前按早期版本的宽松规则调整标量，这是单独的兼容性决定，见scancompat.go。
+*/
+
+package strval
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scalarKind 格式无关的标量类型
+type scalarKind uint8
+
+const (
+	// scalarInvalid 数组、对象、不支持的类型或不合法的字面量
+	scalarInvalid scalarKind = iota
+	scalarNull
+	scalarBool
+	scalarInt
+	scalarFloat
+	scalarString
+	// scalarTime 数据库驱动返回的时间
+	scalarTime
+)
+
+var scalarKindNames = [...]string{"invalid value", "null", "bool", "int", "float", "string", "time"}
+
+// String 返回标量类型的名称
+func (k scalarKind) String() string {
+	return scalarKindNames[k]
+}
+
+// scalar 格式适配器识别出的标量，各类型的coerce方法只依赖它而不依赖具体格式
+type scalar struct {
+	kind scalarKind
+	b    bool
+	i    int64
+	f    float64
+	t    time.Time
+	// text 字符串的内容，或数值、布尔值在输入中的写法，hasText为false时无意义
+	text    string
+	hasText bool
+	// borrowed text引用输入数据的内存，需要保留时必须复制
+	borrowed bool
+	// err kind为scalarInvalid时的原因
+	err error
+}
+
+// textScalar 返回字符串标量
+func textScalar(s string, borrowed bool) scalar {
+	return scalar{kind: scalarString, text: s, hasText: true, borrowed: borrowed}
+}
+
+// invalidScalar 返回无效标量
+func invalidScalar(err error) scalar {
+	return scalar{kind: scalarInvalid, err: err}
+}
+
+// numberScalar 按十进制文本识别数值标量，不含小数点与指数的数字优先解析为整数
+//
+// 说明：超出int64范围的整数按浮点数处理，超出float64范围的数字为无效值，但保留其文本
+func numberScalar(text string, borrowed bool) scalar {
+	v := scalar{text: text, hasText: true, borrowed: borrowed}
+	if !strings.ContainsAny(text, ".eE") {
+		if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+			v.kind, v.i = scalarInt, i
+			return v
+		}
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		v.kind, v.err = scalarInvalid, err
+		return v
+	}
+	v.kind, v.f = scalarFloat, f
+	return v
+}
+
+// str 返回用于字符串解析的文本
+//
+// 说明：预处理器与报告可能保留输入字符串，此时复制引用输入数据的文本
+func (v scalar) str(o *Options) string {
+	if v.borrowed && (o.Report != nil || len(o.Normalizers) > 0 || len(o.TypeNormalizers) > 0) {
+		return strings.Clone(v.text)
+	}
+	return v.text
+}
+
+// convertError 返回标量无法转换为目标类型的错误
+func (v scalar) convertError(target string) error {
+	switch {
+	case v.kind == scalarInvalid && v.err != nil:
+		return v.err
+	case v.hasText:
+		return fmt.Errorf("cannot convert %s %q to %s", v.kind, v.text, target)
+	case v.kind == scalarBool:
+		return fmt.Errorf("cannot convert bool %t to %s", v.b, target)
+	case v.kind == scalarInt:
+		return fmt.Errorf("cannot convert int %d to %s", v.i, target)
+	case v.kind == scalarFloat:
+		return fmt.Errorf("cannot convert float %g to %s", v.f, target)
+	case v.kind == scalarTime:
+		return fmt.Errorf("cannot convert time %s to %s", v.t.Format(time.RFC3339Nano), target)
+	}
+	return fmt.Errorf("cannot convert %s to %s", v.kind, target)
+}
+
+// coerce 将标量转换为Bool
+// 返回值:
+//   - error: 转换失败时的错误，此时值被置为false
+func (b *Bool) coerce(v scalar, o *Options) error {
+	*b = false
+	switch v.kind {
+	case scalarNull:
+		return nil
+	case scalarBool:
+		*b = Bool(v.b)
+		return nil
+	case scalarString:
+		return b.setString(v.str(o), o)
+	case scalarInt:
+		if v.i == 0 || v.i == 1 {
+			*b = v.i == 1
+			return nil
+		}
+	}
+	return v.convertError("Bool")
+}
+
+// coerce 将标量转换为Int
+// 返回值:
+//   - error: 转换失败时的错误，此时值被置为0
+func (i *Int) coerce(v scalar, o *Options) error {
+	*i = 0
+	switch v.kind {
+	case scalarNull:
+		return nil
+	case scalarString:
+		return i.setString(v.str(o), o)
+	case scalarInt:
+		if v.i == int64(int(v.i)) {
+			*i = Int(v.i)
+			return nil
+		}
+	case scalarFloat:
+		// 只允许小数部分为零的浮点数，如1.0与1e2
+		if v.f == math.Trunc(v.f) && v.f >= math.MinInt && v.f < math.MaxInt {
+			*i = Int(v.f)
+			return nil
+		}
+	case scalarTime:
+		if sec := v.t.Unix(); sec == int64(int(sec)) {
+			*i = Int(sec)
+			return nil
+		}
+	}
+	return v.convertError("Int")
+}
+
+// coerce 将标量转换为Float
+// 返回值:
+//   - error: 转换失败时的错误，此时值被置为0
+func (f *Float) coerce(v scalar, o *Options) error {
+	*f = 0
+	switch v.kind {
+	case scalarNull:
+		return nil
+	case scalarString:
+		return f.setString(v.str(o), o)
+	case scalarInt:
+		*f = Float(v.i)
+		return nil
+	case scalarFloat:
+		if err := checkFinite(v.f, o); err != nil {
+			return err
+		}
+		*f = Float(v.f)
+		return nil
+	case scalarTime:
+		*f = Float(float64(v.t.Unix()) + float64(v.t.Nanosecond())/1e9)
+		return nil
+	}
+	return v.convertError("Float")
+}
+
+// coerce 将标量转换为String
+// 返回值:
+//   - error: 转换失败时的错误，此时值被置为空字符串
+//
+// 说明：输入中带有写法的标量（包括不合法的字面量，如YAML 1.2严格模式下的!!bool yes）保留原始写法
+func (s *String) coerce(v scalar, o *Options) error {
+	switch {
+	case v.kind == scalarNull:
+		return s.setString("", o)
+	case v.hasText:
+		// String会保留字符串，需要与输入数据分离
+		text := v.text
+		if v.borrowed {
+			text = strings.Clone(text)
+		}
+		return s.setString(text, o)
+	case v.kind == scalarBool:
+		return s.setString(strconv.FormatBool(v.b), o)
+	case v.kind == scalarInt:
+		return s.setString(strconv.FormatInt(v.i, 10), o)
+	case v.kind == scalarFloat:
+		return s.setString(strconv.FormatFloat(v.f, 'g', -1, 64), o)
+	case v.kind == scalarTime:
+		return s.setString(v.t.Format(time.RFC3339Nano), o)
+	}
+	*s = ""
+	return v.convertError("String")
+}
+
+// coerce 将标量转换为NullBool
+// 返回值:
+//   - error: 转换失败时的错误，此时值被置为无效值
+func (n *NullBool) coerce(v scalar, o *Options) error {
+	*n = NullBool{}
+	switch v.kind {
+	case scalarNull:
+		return nil
+	case scalarBool:
+		*n = NullBool{Bool: v.b, Valid: true}
+		return nil
+	case scalarString:
+		return n.setString(v.str(o), o)
+	case scalarInt:
+		if v.i == 0 || v.i == 1 {
+			*n = NullBool{Bool: v.i == 1, Valid: true}
+			return nil
+		}
+	}
+	return v.convertError("NullBool")
+}
+
+// sqlScalar 将数据库驱动返回的值识别为标量
+//
+// 说明：除driver.Value规定的类型外，还接受MySQL等驱动直接返回的各宽度整数、无符号整数与float32：
+//   - []byte按字符串处理，单个字节0或1按整数处理（MySQL的BIT(1)列）
+//   - 超出int64范围的uint64按浮点数处理，并保留十进制文本供String使用
+//   - float32按最短的十进制形式转换，如float32(0.1)得到0.1而不是0.10000000149011612
+func sqlScalar(value interface{}) scalar {
+	switch v := value.(type) {
+	case nil:
+		return scalar{kind: scalarNull}
+	case bool:
+		return scalar{kind: scalarBool, b: v}
+	case int64:
+		return scalar{kind: scalarInt, i: v}
+	case float64:
+		return scalar{kind: scalarFloat, f: v}
+	case string:
+		return textScalar(v, false)
+	case []byte:
+		if len(v) == 1 && v[0] <= 1 {
+			return scalar{kind: scalarInt, i: int64(v[0])}
+		}
+		// 驱动可能复用缓冲区，String保留前会复制
+		return textScalar(bytesView(v), true)
+	case time.Time:
+		return scalar{kind: scalarTime, t: v}
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return scalar{kind: scalarInt, i: rv.Int()}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := rv.Uint()
+		if u <= math.MaxInt64 {
+			return scalar{kind: scalarInt, i: int64(u)}
+		}
+		return scalar{kind: scalarFloat, f: float64(u), text: strconv.FormatUint(u, 10), hasText: true}
+	case reflect.Float32:
+		f, _ := strconv.ParseFloat(strconv.FormatFloat(rv.Float(), 'g', -1, 32), 64)
+		return scalar{kind: scalarFloat, f: f}
+	case reflect.Float64:
+		return scalar{kind: scalarFloat, f: rv.Float()}
+	}
+	return invalidScalar(fmt.Errorf("unsupported type %T from database", value))
+}
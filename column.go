@@ -0,0 +1,215 @@
+/*
+--------------------------------
+@Create 2026/10/19 03:10
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 03:10
+@Description Bool/NullBool在数据库中的表示形式
+--------------------------------
+遗留的Oracle与MySQL表常以CHAR(1)的'Y'/'N'或'T'/'F'、NUMBER(1)或TINYINT(1)的1/0存储布尔值，
+而Bool.Value总是返回Go的bool，部分驱动会将其写为1/0甚至拒绝写入。本文件定义了写入数据库时的表示形式：
+1. 通过Options.BoolColumn全局设置Bool与NullBool写入的值，Scan同时接受该表示形式，保证往返一致
+2. 通过ColumnBool/ColumnNullBool泛型包装类型为单个字段指定表示形式，不受全局选项影响
+*/
+
+package strval
+
+import (
+	"database/sql/driver"
+	"log/slog"
+	"strings"
+)
+
+// BoolColumnKind 定义布尔值写入数据库时的值类型
+type BoolColumnKind int
+
+const (
+	// BoolColumnNative 写入原生bool，由驱动决定具体写法
+	BoolColumnNative BoolColumnKind = iota
+	// BoolColumnInt 写入整数1/0，用于TINYINT(1)、NUMBER(1)等列
+	BoolColumnInt
+	// BoolColumnChars 写入True/False指定的字符串，用于CHAR(1)等列
+	BoolColumnChars
+)
+
+// BoolColumn 布尔值在数据库中的表示形式，零值写入原生bool
+type BoolColumn struct {
+	// Kind 写入的值类型
+	Kind BoolColumnKind
+	// True Kind为BoolColumnChars时true写入的字符串
+	True string
+	// False Kind为BoolColumnChars时false写入的字符串
+	False string
+}
+
+var (
+	// BoolColumnYN 以"Y"/"N"存储
+	BoolColumnYN = BoolColumn{Kind: BoolColumnChars, True: "Y", False: "N"}
+	// BoolColumnTF 以"T"/"F"存储
+	BoolColumnTF = BoolColumn{Kind: BoolColumnChars, True: "T", False: "F"}
+	// BoolColumnOneZero 以整数1/0存储
+	BoolColumnOneZero = BoolColumn{Kind: BoolColumnInt}
+)
+
+// WithBoolColumn 设置Bool/NullBool写入数据库时的表示形式
+//
+// 说明：Value与Scan没有单次调用的入口，该选项通常配合SetDefaultOptions全局设置
+func WithBoolColumn(c BoolColumn) Option {
+	return func(o *Options) { o.BoolColumn = c }
+}
+
+// value 返回布尔值在该表示形式下写入数据库的值
+func (c BoolColumn) value(b bool) driver.Value {
+	switch c.Kind {
+	case BoolColumnInt:
+		if b {
+			return int64(1)
+		}
+		return int64(0)
+	case BoolColumnChars:
+		if b {
+			return c.True
+		}
+		return c.False
+	default:
+		return b
+	}
+}
+
+// match 判断数据库返回的字符串是否为该表示形式的真/假值
+// 返回值:
+//   - bool: 布尔值
+//   - bool: 是否命中
+//
+// 说明：匹配时不区分大小写并去除前后空白，以便读取CHAR(n)列补齐的空格；未命中时按布尔值词汇表解析
+func (c BoolColumn) match(v scalar) (bool, bool) {
+	if c.Kind != BoolColumnChars || v.kind != scalarString {
+		return false, false
+	}
+	s := strings.TrimSpace(v.text)
+	switch {
+	case strings.EqualFold(s, c.True):
+		return true, true
+	case strings.EqualFold(s, c.False):
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// scan 按表示形式从数据库读取Bool，供Bool与ColumnBool共用
+func (b *Bool) scan(value interface{}, c BoolColumn, o *Options) {
+	v := sqlScalar(value)
+	if x, ok := c.match(v); ok {
+		*b = Bool(x)
+		return
+	}
+	if err := b.coerce(scanBoolScalar(v), o); err != nil {
+		slog.Error("invalid Bool value from database", "error", err)
+	}
+}
+
+// scan 按表示形式从数据库读取NullBool，供NullBool与ColumnNullBool共用
+func (n *NullBool) scan(value interface{}, c BoolColumn, o *Options) {
+	v := sqlScalar(value)
+	if x, ok := c.match(v); ok {
+		*n = NullBool{Bool: x, Valid: true}
+		return
+	}
+	if err := n.coerce(scanBoolScalar(v), o); err != nil {
+		slog.Error("invalid NullBool value from database", "error", err)
+	}
+}
+
+// BoolColumner 为ColumnBool/ColumnNullBool提供字段级的数据库表示形式
+//
+// 除内置的ColumnYN、ColumnTF与ColumnOneZero外，可以自定义空结构体实现其他字符对：
+//
+//	type ColumnAB struct{}
+//
+//	func (ColumnAB) BoolColumn() strval.BoolColumn {
+//		return strval.BoolColumn{Kind: strval.BoolColumnChars, True: "A", False: "B"}
+//	}
+type BoolColumner interface {
+	BoolColumn() BoolColumn
+}
+
+// ColumnYN 以"Y"/"N"存储的字段表示形式
+type ColumnYN struct{}
+
+// BoolColumn 实现BoolColumner接口
+func (ColumnYN) BoolColumn() BoolColumn { return BoolColumnYN }
+
+// ColumnTF 以"T"/"F"存储的字段表示形式
+type ColumnTF struct{}
+
+// BoolColumn 实现BoolColumner接口
+func (ColumnTF) BoolColumn() BoolColumn { return BoolColumnTF }
+
+// ColumnOneZero 以整数1/0存储的字段表示形式
+type ColumnOneZero struct{}
+
+// BoolColumn 实现BoolColumner接口
+func (ColumnOneZero) BoolColumn() BoolColumn { return BoolColumnOneZero }
+
+// ColumnBool 以C指定的表示形式读写数据库的Bool，JSON/YAML等其他格式的行为与Bool相同
+//
+// 示例:
+//
+//	type User struct {
+//		Active  strval.ColumnBool[strval.ColumnYN]      `gorm:"column:active"`
+//		Deleted strval.ColumnBool[strval.ColumnOneZero] `gorm:"column:deleted"`
+//	}
+type ColumnBool[C BoolColumner] struct {
+	Bool
+}
+
+// Value 实现driver.Valuer接口，按C的表示形式写入数据库
+// 返回值:
+//   - driver.Value: 数据库可接受的值
+//   - error: 转换过程中的错误
+func (b ColumnBool[C]) Value() (driver.Value, error) {
+	var c C
+	return c.BoolColumn().value(bool(b.Bool)), nil
+}
+
+// Scan 实现sql.Scanner接口，先匹配C的表示形式，未命中时按Bool.Scan的规则解析
+// 参数:
+//   - value: 从数据库读取的值
+//
+// 返回值:
+//   - error: 扫描过程中的错误
+func (b *ColumnBool[C]) Scan(value interface{}) error {
+	var c C
+	b.Bool.scan(value, c.BoolColumn(), currentOptions())
+	return nil
+}
+
+// ColumnNullBool 以C指定的表示形式读写数据库的NullBool，无效值写入NULL
+type ColumnNullBool[C BoolColumner] struct {
+	NullBool
+}
+
+// Value 实现driver.Valuer接口，按C的表示形式写入数据库，无效值写入NULL
+// 返回值:
+//   - driver.Value: 数据库可接受的值
+//   - error: 转换过程中的错误
+func (n ColumnNullBool[C]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	var c C
+	return c.BoolColumn().value(n.Bool), nil
+}
+
+// Scan 实现sql.Scanner接口，先匹配C的表示形式，未命中时按NullBool.Scan的规则解析
+// 参数:
+//   - value: 从数据库读取的值
+//
+// 返回值:
+//   - error: 扫描过程中的错误
+func (n *ColumnNullBool[C]) Scan(value interface{}) error {
+	var c C
+	n.NullBool.scan(value, c.BoolColumn(), currentOptions())
+	return nil
+}
@@ -0,0 +1,339 @@
+/*
+--------------------------------
+@Create 2026/10/18 22:10
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 22:10
+@Description CSV记录与结构体的映射
+--------------------------------
+CSV中的每个单元格都是字符串，本文件提供CSVReader与CSVWriter，按表头将列映射到带csv标签的结构体字段：
+1. CSVReader逐行读取，适合流式处理大文件；单元格使用与UnmarshalJSON中字符串相同的宽松解析逻辑，
+   解析失败的单元格置为零值，并以包含行号、列号与列名的CSVError报告，不影响后续行的读取
+2. CSVWriter在第一次写入时输出表头，布尔值与数字的输出形式由BoolOutput、FloatFormat等选项以及字段上的strval标签控制
+
+字段使用以下标签，没有csv标签时使用字段名，表头按不区分大小写匹配，匿名嵌入的结构体展开到同一层：
+
+	Name    strval.String `csv:"name"`
+	Price   strval.Float  `csv:"price" strval:"decimals=2"`
+	OnSale  strval.Bool   `csv:"on_sale" strval:"bool=yn"`
+	Comment string        `csv:"-"`
+*/
+
+package strval
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// CSVError CSV单元格错误，CSVReader返回的行错误由若干CSVError组成，可使用errors.As获取
+type CSVError struct {
+	// Line 单元格所在的行号，从1开始
+	Line int
+	// Column 单元格所在的列号，从1开始
+	Column int
+	// Header 列名
+	Header string
+	// Value 单元格的原始内容
+	Value string
+	// Err 具体的错误原因
+	Err error
+}
+
+// Error 实现error接口
+func (e *CSVError) Error() string {
+	return fmt.Sprintf("strval: csv line %d, column %d (%s): %v", e.Line, e.Column, e.Header, e.Err)
+}
+
+// Unwrap 返回具体的错误原因
+func (e *CSVError) Unwrap() error {
+	return e.Err
+}
+
+// csvColumn 表头中的一列与结构体字段的对应关系
+type csvColumn struct {
+	// field 对应的字段，表头中没有对应字段的列为nil
+	field *structField
+	// o 字段生效的选项
+	o *Options
+}
+
+// CSVReader 按表头将CSV记录读取到结构体
+type CSVReader struct {
+	// Reader 底层的csv.Reader，可以在第一次读取前设置Comma、Comment、LazyQuotes等
+	Reader *csv.Reader
+
+	o       *Options
+	header  []string
+	typ     reflect.Type
+	columns []csvColumn
+}
+
+// NewCSVReader 创建CSVReader，第一行作为表头
+// 参数:
+//   - r: CSV输入
+//   - opts: 单元格解析时使用的单次调用选项
+func NewCSVReader(r io.Reader, opts ...Option) *CSVReader {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	return &CSVReader{Reader: cr, o: resolveOptions(opts)}
+}
+
+// Header 返回表头，尚未读取时读取第一行
+func (r *CSVReader) Header() ([]string, error) {
+	if r.header == nil {
+		record, err := r.Reader.Read()
+		if err != nil {
+			return nil, err
+		}
+		r.header = append([]string(nil), record...)
+	}
+	return r.header, nil
+}
+
+// Read 读取下一行到结构体
+// 参数:
+//   - v: 结构体指针，每次读取前会被置为零值
+//
+// 返回值:
+//   - error: 没有更多数据时返回io.EOF；CSV格式错误原样返回；
+//     单元格解析失败时返回该行所有*CSVError汇总后的错误，此时v中其余字段仍然有效，可以继续读取下一行
+//
+// 说明：空单元格解析为零值（NullBool为无效值），不作为错误
+func (r *CSVReader) Read(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("strval: CSVReader.Read requires a non-nil struct pointer, got %T", v)
+	}
+	if _, err := r.Header(); err != nil {
+		return err
+	}
+	if err := r.bind(rv.Elem().Type()); err != nil {
+		return err
+	}
+	record, err := r.Reader.Read()
+	if err != nil {
+		return err
+	}
+
+	sv := rv.Elem()
+	sv.SetZero()
+	var errs []error
+	for i, cell := range record {
+		if i >= len(r.columns) || r.columns[i].field == nil || cell == "" {
+			continue
+		}
+		col := r.columns[i]
+		if err := setFieldText(fieldByIndex(sv, col.field.index), cell, col.o); err != nil {
+			line, column := r.Reader.FieldPos(i)
+			errs = append(errs, &CSVError{Line: line, Column: column, Header: r.header[i], Value: cell, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ReadAll 读取所有剩余的行
+// 参数:
+//   - v: 结构体切片的指针，如*[]Product
+//
+// 返回值:
+//   - error: CSV格式错误时立即返回；所有行的单元格错误汇总后返回，此时v中包含所有读取到的行
+func (r *CSVReader) ReadAll(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Slice ||
+		rv.Elem().Type().Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("strval: CSVReader.ReadAll requires a pointer to a slice of structs, got %T", v)
+	}
+	slice := rv.Elem()
+	var errs []error
+	for {
+		item := reflect.New(slice.Type().Elem())
+		err := r.Read(item.Interface())
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var cellErr *CSVError
+			if !errors.As(err, &cellErr) {
+				return err
+			}
+			errs = append(errs, err)
+		}
+		slice.Set(reflect.Append(slice, item.Elem()))
+	}
+	return errors.Join(errs...)
+}
+
+// bind 建立表头各列与结构体字段的对应关系，按类型缓存
+func (r *CSVReader) bind(t reflect.Type) error {
+	if r.typ == t {
+		return nil
+	}
+	fields := cachedFields(t, "csv")
+	columns := make([]csvColumn, len(r.header))
+	for i, name := range r.header {
+		f := lookupField(fields, name, true)
+		if f == nil {
+			continue
+		}
+		fo, err := fieldOptions(r.o, f.tag)
+		if err != nil {
+			return err
+		}
+		columns[i] = csvColumn{field: f, o: fo}
+	}
+	r.typ, r.columns = t, columns
+	return nil
+}
+
+// setFieldText 从文本设置字段值，指针字段会被分配，供CSV、key=value与INI的解码共用
+func setFieldText(fv reflect.Value, text string, o *Options) error {
+	if fv.Kind() == reflect.Pointer {
+		fv.Set(reflect.New(fv.Type().Elem()))
+		fv = fv.Elem()
+	}
+	return setFieldString(fv, text, o)
+}
+
+// CSVWriter 将结构体写为CSV记录
+type CSVWriter struct {
+	// Writer 底层的csv.Writer，可以在第一次写入前设置Comma、UseCRLF等
+	Writer *csv.Writer
+	// NoHeader 为true时不输出表头
+	NoHeader bool
+
+	o      *Options
+	typ    reflect.Type
+	fields []structField
+	opts   []*Options
+	record []string
+}
+
+// NewCSVWriter 创建CSVWriter
+// 参数:
+//   - w: CSV输出
+//   - opts: 单元格输出时使用的单次调用选项，如WithBoolOutput(BoolOutputYesNo)、WithFloatFormat(FloatFixed(2))
+func NewCSVWriter(w io.Writer, opts ...Option) *CSVWriter {
+	return &CSVWriter{Writer: csv.NewWriter(w), o: resolveOptions(opts)}
+}
+
+// Write 写入一行，第一次写入时先输出表头
+// 参数:
+//   - v: 结构体或结构体指针，同一个CSVWriter只能写入同一种类型
+//
+// 返回值:
+//   - error: 类型不一致、字段类型不支持或写入失败时的错误
+//
+// 说明：与csv.Writer一致，写入的内容可能被缓冲，结束时需要调用Flush
+func (w *CSVWriter) Write(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("strval: CSVWriter.Write requires a struct, got %T", v)
+	}
+	if err := w.bind(rv.Type()); err != nil {
+		return err
+	}
+
+	for i, f := range w.fields {
+		fv, ok := fieldValue(rv, f.index)
+		if !ok {
+			w.record[i] = ""
+			continue
+		}
+		cell, err := formatFieldString(fv, w.opts[i])
+		if err != nil {
+			return fmt.Errorf("strval: csv column %s: %w", f.name, err)
+		}
+		w.record[i] = cell
+	}
+	return w.Writer.Write(w.record)
+}
+
+// WriteAll 写入切片中的所有结构体并调用Flush
+func (w *CSVWriter) WriteAll(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Errorf("strval: CSVWriter.WriteAll requires a slice, got %T", v)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if err := w.Write(rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// Flush 将缓冲的内容写入底层输出，并返回写入过程中的错误
+func (w *CSVWriter) Flush() error {
+	w.Writer.Flush()
+	return w.Writer.Error()
+}
+
+// bind 确定输出的字段并输出表头
+func (w *CSVWriter) bind(t reflect.Type) error {
+	if w.typ == t {
+		return nil
+	}
+	if w.typ != nil {
+		return fmt.Errorf("strval: CSVWriter.Write got %s after %s", t, w.typ)
+	}
+	fields := orderedFields(t, "csv")
+	opts := make([]*Options, len(fields))
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		fo, err := fieldOptions(w.o, f.tag)
+		if err != nil {
+			return err
+		}
+		opts[i], header[i] = fo, f.name
+	}
+	w.typ, w.fields, w.opts, w.record = t, fields, opts, make([]string, len(fields))
+	if w.NoHeader {
+		return nil
+	}
+	return w.Writer.Write(header)
+}
+
+// formatFieldString 按选项将字段值格式化为字符串，nil指针输出空字符串，供CSVWriter与键值对编码共用
+func formatFieldString(fv reflect.Value, o *Options) (string, error) {
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return "", nil
+		}
+		fv = fv.Elem()
+	}
+	switch m := fv.Interface().(type) {
+	case optionTextMarshaler:
+		text, err := m.marshalText(o)
+		return string(text), err
+	case encoding.TextMarshaler:
+		text, err := m.MarshalText()
+		return string(text), err
+	}
+	if fv.Type() == durationType {
+		return fv.Interface().(fmt.Stringer).String(), nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		return fv.String(), nil
+	case reflect.Bool:
+		return o.boolText(fv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(fv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(fv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		text, err := Float(fv.Float()).marshalText(o)
+		return string(text), err
+	}
+	return "", fmt.Errorf("unsupported field type %s", fv.Type())
+}
@@ -0,0 +1,276 @@
+/*
+--------------------------------
+@Create 2026/10/18 12:20
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 12:20
+@Description 按调用指定选项的JSON/YAML反序列化
+--------------------------------
+encoding/json与yaml.v3调用UnmarshalJSON/UnmarshalYAML时无法传入额外参数，因此这些方法只能使用全局选项。
+本文件提供DecodeJSON（DecodeYAML位于yamlcodec.go），按结构体标签遍历目标值，对strval类型的字段使用本次调用的选项解析，
+其余字段仍交给encoding/json或yaml.v3处理，行为与直接调用json.Unmarshal/yaml.Unmarshal保持一致。
+字段上的strval标签会在本次调用选项的基础上进一步覆盖该字段的选项。
+*/
+
+package strval
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// optionUnmarshaler 由各strval类型实现，支持按指定选项反序列化
+type optionUnmarshaler interface {
+	unmarshalJSON(data []byte, o *Options)
+}
+
+var (
+	optionUnmarshalerType = reflect.TypeFor[optionUnmarshaler]()
+	jsonUnmarshalerType   = reflect.TypeFor[json.Unmarshaler]()
+)
+
+// DecodeJSON 使用指定选项将JSON数据反序列化到v
+// 参数:
+//   - data: JSON数据字节
+//   - v: 目标值指针
+//   - opts: 覆盖全局选项的单次调用选项
+//
+// 返回值:
+//   - error: JSON结构错误、strval标签错误或非strval字段的反序列化错误；strval字段的解析失败与UnmarshalJSON一致只记录日志
+func DecodeJSON(data []byte, v any, opts ...Option) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return &json.InvalidUnmarshalError{Type: reflect.TypeOf(v)}
+	}
+	if !json.Valid(data) {
+		// 交给encoding/json生成标准的语法错误
+		return json.Unmarshal(data, v)
+	}
+	return decodeJSONValue(data, rv.Elem(), resolveOptions(opts))
+}
+
+// decodeJSONValue 将JSON数据反序列化到可寻址的值
+func decodeJSONValue(data []byte, v reflect.Value, o *Options) error {
+	if !containsStrval(v.Type()) {
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+	if u, ok := v.Addr().Interface().(optionUnmarshaler); ok {
+		u.unmarshalJSON(data, o)
+		return nil
+	}
+	if v.Addr().Type().Implements(jsonUnmarshalerType) {
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+
+	null := bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+	switch v.Kind() {
+	case reflect.Pointer:
+		if null {
+			v.SetZero()
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeJSONValue(data, v.Elem(), o)
+
+	case reflect.Struct:
+		if null {
+			return nil
+		}
+		members, err := jsonMembers(data)
+		if err != nil {
+			return err
+		}
+		fields := cachedFields(v.Type(), "json")
+		for _, m := range members {
+			f := lookupField(fields, m.key, true)
+			if f == nil {
+				continue
+			}
+			fo, err := fieldOptions(o, f.tag)
+			if err != nil {
+				return err
+			}
+			if err := decodeJSONValue(m.value, fieldByIndex(v, f.index), fo.at(f.name)); err != nil {
+				return shiftJSONError(err, m.offset)
+			}
+		}
+		return nil
+
+	case reflect.Slice:
+		if null {
+			v.SetZero()
+			return nil
+		}
+		items, err := jsonItems(data)
+		if err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := decodeJSONValue(item.value, s.Index(i), o.at(indexPath(i))); err != nil {
+				return shiftJSONError(err, item.offset)
+			}
+		}
+		v.Set(s)
+		return nil
+
+	case reflect.Array:
+		if null {
+			return nil
+		}
+		items, err := jsonItems(data)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < v.Len(); i++ {
+			if i >= len(items) {
+				v.Index(i).SetZero()
+				continue
+			}
+			if err := decodeJSONValue(items[i].value, v.Index(i), o.at(indexPath(i))); err != nil {
+				return shiftJSONError(err, items[i].offset)
+			}
+		}
+		return nil
+
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return json.Unmarshal(data, v.Addr().Interface())
+		}
+		if null {
+			v.SetZero()
+			return nil
+		}
+		members, err := jsonMembers(data)
+		if err != nil {
+			return err
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(v.Type(), len(members)))
+		}
+		for _, m := range members {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeJSONValue(m.value, elem, o.at(m.key)); err != nil {
+				return shiftJSONError(err, m.offset)
+			}
+			v.SetMapIndex(reflect.ValueOf(m.key).Convert(v.Type().Key()), elem)
+		}
+		return nil
+	}
+	return json.Unmarshal(data, v.Addr().Interface())
+}
+
+// jsonMember JSON对象中的一个成员或数组中的一个元素
+type jsonMember struct {
+	key   string
+	value json.RawMessage
+	// offset 值在所属JSON数据中的起始偏移
+	offset int
+}
+
+// jsonMembers 按文档顺序读取JSON对象的成员，使解析顺序与报告记录保持稳定
+func jsonMembers(data []byte) ([]jsonMember, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil {
+		return nil, err
+	} else if tok != json.Delim('{') {
+		// 交给encoding/json生成标准的类型错误
+		var members map[string]json.RawMessage
+		return nil, json.Unmarshal(data, &members)
+	}
+	var members []jsonMember
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var m jsonMember
+		m.key, _ = tok.(string)
+		if err := dec.Decode(&m.value); err != nil {
+			return nil, err
+		}
+		m.offset = int(dec.InputOffset()) - len(m.value)
+		members = append(members, m)
+	}
+	return members, nil
+}
+
+// jsonItems 读取JSON数组的元素及其偏移
+func jsonItems(data []byte) ([]jsonMember, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil {
+		return nil, err
+	} else if tok != json.Delim('[') {
+		// 交给encoding/json生成标准的类型错误
+		var items []json.RawMessage
+		return nil, json.Unmarshal(data, &items)
+	}
+	var items []jsonMember
+	for dec.More() {
+		var item jsonMember
+		if err := dec.Decode(&item.value); err != nil {
+			return nil, err
+		}
+		item.offset = int(dec.InputOffset()) - len(item.value)
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// shiftJSONError 将子值中的类型错误偏移转换为所属JSON数据中的偏移，使DecodeJSON的错误偏移与json.Unmarshal一致
+func shiftJSONError(err error, offset int) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		typeErr.Offset += int64(offset)
+	}
+	return err
+}
+
+// indexPath 返回下标形式的路径片段，如"[3]"
+func indexPath(i int) string {
+	return "[" + strconv.Itoa(i) + "]"
+}
+
+// strvalTypeCache 缓存各类型是否包含strval类型
+var strvalTypeCache sync.Map
+
+// containsStrval 判断类型本身或其元素、字段中是否包含strval类型
+//
+// 说明：不包含strval类型的值可以直接交给encoding/json或yaml.v3处理
+func containsStrval(t reflect.Type) bool {
+	if r, ok := strvalTypeCache.Load(t); ok {
+		return r.(bool)
+	}
+	r := containsStrvalType(t, map[reflect.Type]bool{})
+	strvalTypeCache.Store(t, r)
+	return r
+}
+
+// containsStrvalType containsStrval的递归实现，visiting用于避免递归类型死循环
+func containsStrvalType(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	if reflect.PointerTo(t).Implements(optionUnmarshalerType) {
+		return true
+	}
+	if visiting[t] {
+		return false
+	}
+	visiting[t] = true
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return containsStrvalType(t.Elem(), visiting)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if containsStrvalType(t.Field(i).Type, visiting) {
+				return true
+			}
+		}
+	}
+	return false
+}
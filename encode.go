@@ -0,0 +1,198 @@
+/*
+--------------------------------
+@Create 2026/10/18 17:40
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 17:40
+@Description 按调用指定选项的JSON/YAML序列化
+--------------------------------
+与decode.go对应，encoding/json与yaml.v3调用MarshalJSON/MarshalYAML时只能使用全局选项。
+本文件提供EncodeJSON（EncodeYAML位于yamlcodec.go），按结构体标签遍历值，对strval类型的字段使用本次调用的选项序列化，
+其余字段仍交给encoding/json或yaml.v3处理。字段上的strval标签会进一步覆盖该字段的选项。
+*/
+
+package strval
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"slices"
+)
+
+// optionMarshaler 由各strval类型实现，支持按指定选项序列化
+type optionMarshaler interface {
+	marshalJSON(o *Options) ([]byte, error)
+}
+
+var jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+
+// EncodeJSON 使用指定选项将v序列化为JSON
+// 参数:
+//   - v: 需要序列化的值
+//   - opts: 覆盖全局选项的单次调用选项
+//
+// 返回值:
+//   - []byte: 序列化后的JSON字节
+//   - error: 序列化过程中的错误或strval标签错误
+func EncodeJSON(v any, opts ...Option) ([]byte, error) {
+	var buf jsonBuffer
+	if err := encodeJSONValue(&buf, reflect.ValueOf(v), resolveOptions(opts)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeJSONValue 将值序列化为JSON并写入buf
+func encodeJSONValue(buf *jsonBuffer, v reflect.Value, o *Options) error {
+	if !v.IsValid() {
+		buf.WriteString("null")
+		return nil
+	}
+	// nil指针与接口在类型断言之前处理，值接收者的方法不能通过nil指针调用
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		buf.WriteString("null")
+		return nil
+	}
+	if v.Kind() == reflect.Interface {
+		return encodeJSONValue(buf, v.Elem(), o)
+	}
+	if m, ok := v.Interface().(optionMarshaler); ok {
+		return buf.writeValue(m.marshalJSON(o))
+	}
+	if !containsStrval(v.Type()) || v.Type().Implements(jsonMarshalerType) {
+		return buf.writeValue(json.Marshal(v.Interface()))
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		return encodeJSONValue(buf, v.Elem(), o)
+
+	case reflect.Struct:
+		buf.WriteByte('{')
+		first := true
+		for _, f := range cachedFields(v.Type(), "json") {
+			fv, ok := fieldValue(v, f.index)
+			if !ok || (f.hasOpt("omitempty") && isEmptyValue(fv)) || (f.hasOpt("omitzero") && fv.IsZero()) {
+				continue
+			}
+			fo, err := fieldOptions(o, f.tag)
+			if err != nil {
+				return err
+			}
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+			if err := buf.writeValue(json.Marshal(f.name)); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := encodeJSONValue(buf, fv, fo); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		buf.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeJSONValue(buf, v.Index(i), o); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return buf.writeValue(json.Marshal(v.Interface()))
+		}
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		buf.WriteByte('{')
+		for i, key := range sortedMapKeys(v) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := buf.writeValue(json.Marshal(key.String())); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := encodeJSONValue(buf, v.MapIndex(key), o); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	}
+	return buf.writeValue(json.Marshal(v.Interface()))
+}
+
+// jsonBuffer EncodeJSON使用的输出缓冲区
+type jsonBuffer struct {
+	bytes.Buffer
+}
+
+// writeValue 将序列化结果写入缓冲区，可直接接收json.Marshal等函数的返回值
+func (b *jsonBuffer) writeValue(data []byte, err error) error {
+	if err != nil {
+		return err
+	}
+	b.Write(data)
+	return nil
+}
+
+// sortedMapKeys 返回按字符串排序的映射键，与encoding/json的输出顺序一致
+func sortedMapKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		switch {
+		case a.String() < b.String():
+			return -1
+		case a.String() > b.String():
+			return 1
+		}
+		return 0
+	})
+	return keys
+}
+
+// fieldValue 按索引路径获取字段值，路径上存在nil嵌入指针时返回false
+func fieldValue(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// isEmptyValue 判断值是否为空，规则与encoding/json的omitempty一致
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
@@ -0,0 +1,343 @@
+/*
+--------------------------------
+@Create 2026/10/18 20:10
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 20:10
+@Description 从环境变量与.env文件加载结构体
+--------------------------------
+环境变量天然是字符串，本文件提供LoadEnv与DecodeEnv，按结构体字段从环境变量读取配置，
+strval类型的字段使用与UnmarshalJSON中字符串相同的宽松解析逻辑，字段上的strval标签同样生效。
+与其他反序列化方法不同，加载时会收集所有无效或缺失的变量，以一个汇总的错误返回。
+
+字段使用以下标签：
+
+	Port    strval.Int  `env:"PORT,required"`
+	Debug   strval.Bool `env:"DEBUG" default:"false"`
+	DB      DBConfig    `env:"DB"`
+	Secret  string      `env:"-"`
+
+变量名取env标签中的名称，没有时使用yaml/json标签中的名称或字段名，转换为大写下划线形式（MaxCount→MAX_COUNT）；
+嵌套结构体的变量名以"_"连接，如前缀为APP时DB.Port对应APP_DB_PORT；匿名嵌入的结构体展开到上一层。
+
+.env文件每行一个KEY=VALUE，支持#注释、export前缀以及单引号、双引号包裹的值，
+LoadDotEnv只设置进程中尚未存在的变量，因此真实的环境变量优先于.env文件。
+*/
+
+package strval
+
+import (
+	"bufio"
+	"encoding"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+)
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// EnvError 环境变量错误，LoadEnv与DecodeEnv返回的汇总错误由若干EnvError组成，可使用errors.As获取
+type EnvError struct {
+	// Name 变量名
+	Name string
+	// Value 变量值，变量缺失时为空
+	Value string
+	// Err 具体的错误原因
+	Err error
+}
+
+// Error 实现error接口
+func (e *EnvError) Error() string {
+	return fmt.Sprintf("strval: environment variable %s: %v", e.Name, e.Err)
+}
+
+// Unwrap 返回具体的错误原因
+func (e *EnvError) Unwrap() error {
+	return e.Err
+}
+
+// errEnvRequired 必需的变量未设置
+var errEnvRequired = errors.New("required variable is not set")
+
+// LoadEnv 从当前进程的环境变量加载结构体
+// 参数:
+//   - v: 结构体指针
+//   - prefix: 变量名前缀，如"APP"，为空时不加前缀
+//   - opts: strval字段解析时使用的单次调用选项
+//
+// 返回值:
+//   - error: 所有无效或缺失的变量汇总后的错误，每一项为*EnvError
+func LoadEnv(v any, prefix string, opts ...Option) error {
+	return decodeEnv(os.LookupEnv, v, prefix, opts)
+}
+
+// DecodeEnv 从指定的变量集合加载结构体，规则与LoadEnv相同
+// 参数:
+//   - env: 变量集合，如ReadDotEnv的返回值
+//   - v: 结构体指针
+//   - prefix: 变量名前缀
+//   - opts: strval字段解析时使用的单次调用选项
+//
+// 返回值:
+//   - error: 所有无效或缺失的变量汇总后的错误，每一项为*EnvError
+func DecodeEnv(env map[string]string, v any, prefix string, opts ...Option) error {
+	return decodeEnv(func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}, v, prefix, opts)
+}
+
+// decodeEnv 使用lookup查找变量并加载结构体
+func decodeEnv(lookup func(string) (string, bool), v any, prefix string, opts []Option) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("strval: DecodeEnv requires a non-nil struct pointer, got %T", v)
+	}
+	if prefix != "" && !strings.HasSuffix(prefix, "_") {
+		prefix += "_"
+	}
+	d := envDecoder{lookup: lookup}
+	d.decodeStruct(rv.Elem(), prefix, resolveOptions(opts))
+	return errors.Join(d.errs...)
+}
+
+// envDecoder 加载环境变量时的状态
+type envDecoder struct {
+	lookup func(string) (string, bool)
+	errs   []error
+}
+
+// decodeStruct 加载结构体的各字段
+func (d *envDecoder) decodeStruct(v reflect.Value, prefix string, o *Options) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, ok := envName(sf)
+		if !ok || (!sf.IsExported() && !sf.Anonymous) {
+			continue
+		}
+		fv := v.Field(i)
+
+		if fv.Kind() == reflect.Pointer && fv.Type().Elem().Kind() == reflect.Struct && !isEnvValue(fv.Type()) {
+			if !sf.IsExported() {
+				continue
+			}
+			if fv.IsNil() {
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct && !isEnvValue(fv.Addr().Type()) {
+			sub := prefix
+			if _, tagged := sf.Tag.Lookup("env"); !sf.Anonymous || tagged {
+				sub = prefix + name + "_"
+			}
+			d.decodeStruct(fv, sub, o)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		fo, err := fieldOptions(o, sf.Tag)
+		if err != nil {
+			d.errs = append(d.errs, &EnvError{Name: prefix + name, Err: err})
+			continue
+		}
+		d.decodeField(fv, prefix+name, sf.Tag, fo)
+	}
+}
+
+// decodeField 加载单个字段
+func (d *envDecoder) decodeField(fv reflect.Value, name string, tag reflect.StructTag, o *Options) {
+	value, ok := d.lookup(name)
+	if !ok {
+		def, hasDefault := tag.Lookup("default")
+		switch {
+		case hasDefault && fv.IsZero():
+			if err := setFieldString(fv, def, o); err != nil {
+				d.errs = append(d.errs, &EnvError{Name: name, Value: def, Err: fmt.Errorf("invalid default: %w", err)})
+			}
+		case !hasDefault && envRequired(tag):
+			d.errs = append(d.errs, &EnvError{Name: name, Err: errEnvRequired})
+		}
+		return
+	}
+	if err := setFieldString(fv, value, o); err != nil {
+		d.errs = append(d.errs, &EnvError{Name: name, Value: value, Err: err})
+	}
+}
+
+// isEnvValue 判断类型是否可以直接从单个变量加载
+func isEnvValue(t reflect.Type) bool {
+	return isFlagValue(t) || t.Implements(textUnmarshalerType)
+}
+
+// envRequired 判断字段是否带有required选项
+func envRequired(tag reflect.StructTag) bool {
+	_, opts, _ := strings.Cut(tag.Get("env"), ",")
+	return hasTagOpt(opts, "required")
+}
+
+// envName 返回字段对应的变量名，env:"-"时返回false
+func envName(sf reflect.StructField) (string, bool) {
+	if tag, ok := sf.Tag.Lookup("env"); ok {
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			return "", false
+		}
+		if name != "" {
+			return name, true
+		}
+	}
+	for _, key := range []string{"yaml", "json"} {
+		name, _, _ := strings.Cut(sf.Tag.Get(key), ",")
+		if name != "" && name != "-" {
+			return envCase(name), true
+		}
+	}
+	return envCase(kebabCase(sf.Name)), true
+}
+
+// envCase 转换为大写下划线形式，如max-count→MAX_COUNT
+func envCase(name string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
+
+// ReadDotEnv 解析.env格式的内容
+// 参数:
+//   - r: .env内容
+//
+// 返回值:
+//   - map[string]string: 解析得到的变量
+//   - error: 格式错误，包含行号
+//
+// 说明：支持#注释、export前缀、单引号（原样保留）与双引号（支持\n、\t、\"、\\转义）包裹的值，
+// 未加引号的值去除首尾空白以及" #"之后的行内注释
+func ReadDotEnv(r io.Reader) (map[string]string, error) {
+	env := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		text = strings.TrimPrefix(text, "export ")
+		key, value, ok := strings.Cut(text, "=")
+		key = strings.TrimSpace(key)
+		if !ok || !validEnvKey(key) {
+			return nil, fmt.Errorf("strval: line %d: invalid .env entry %q", line, scanner.Text())
+		}
+		value, err := dotEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("strval: line %d: %s: %w", line, key, err)
+		}
+		env[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// ReadDotEnvFile 读取并解析.env文件，错误信息包含文件名与行号
+func ReadDotEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	env, err := ReadDotEnv(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return env, nil
+}
+
+// LoadDotEnv 读取.env文件并设置到当前进程的环境变量中
+// 参数:
+//   - paths: .env文件路径，为空时读取当前目录下的.env
+//
+// 返回值:
+//   - error: 文件读取或格式错误
+//
+// 说明：已经存在的环境变量不会被覆盖；多个文件中存在同名变量时以先出现的为准
+func LoadDotEnv(paths ...string) error {
+	if len(paths) == 0 {
+		paths = []string{".env"}
+	}
+	for _, path := range paths {
+		env, err := ReadDotEnvFile(path)
+		if err != nil {
+			return err
+		}
+		for key, value := range env {
+			if _, ok := os.LookupEnv(key); ok {
+				continue
+			}
+			if err := os.Setenv(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validEnvKey 判断变量名是否合法
+func validEnvKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i, r := range key {
+		switch {
+		case r == '_', r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z':
+		case i > 0 && (r >= '0' && r <= '9' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// dotEnvValue 解析.env中的值
+func dotEnvValue(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	switch quote := s[0]; quote {
+	case '\'', '"':
+		end := closingQuote(s, quote)
+		if end < 0 {
+			return "", errors.New("unterminated quoted value")
+		}
+		if rest := strings.TrimSpace(s[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", fmt.Errorf("unexpected characters after quoted value: %q", rest)
+		}
+		if quote == '\'' {
+			return s[1:end], nil
+		}
+		return strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\"`, `"`, `\\`, `\`).Replace(s[1:end]), nil
+	}
+	if i := strings.Index(s, " #"); i >= 0 {
+		s = strings.TrimSpace(s[:i])
+	}
+	return s, nil
+}
+
+// closingQuote 返回与s[0]配对的引号位置，双引号中跳过转义字符，不存在时返回-1
+func closingQuote(s string, quote byte) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,190 @@
+/*
+--------------------------------
+@Create 2026/10/18 12:20
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 12:20
+@Description 结构体字段反射辅助函数
+--------------------------------
+本文件实现了按结构体标签（json、yaml等）收集字段的逻辑，规则与encoding/json、yaml.v3基本一致：
+嵌入结构体的字段会被提升，较浅层级的同名字段优先。结果按类型与标签键缓存。
+*/
+
+package strval
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// structField 描述结构体中参与编解码的一个字段
+type structField struct {
+	// name 编解码使用的键名
+	name string
+	// index 字段索引路径，嵌入字段的路径长度大于1
+	index []int
+	// typ 字段类型
+	typ reflect.Type
+	// tag 字段的完整结构体标签
+	tag reflect.StructTag
+	// opts 标签中键名之后的选项部分，如"omitempty,string"
+	opts string
+}
+
+// hasOpt 判断字段标签是否包含指定选项
+func (f *structField) hasOpt(name string) bool {
+	return hasTagOpt(f.opts, name)
+}
+
+// hasTagOpt 判断以逗号分隔的标签选项中是否包含指定选项，忽略选项前后的空白，如`env:"PORT, required"`
+func hasTagOpt(opts, name string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if strings.TrimSpace(opt) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldCacheKey 字段缓存的键
+type fieldCacheKey struct {
+	t      reflect.Type
+	tagKey string
+}
+
+// fieldCache 缓存各类型按标签键收集的字段列表
+var fieldCache sync.Map
+
+// cachedFields 获取结构体类型按指定标签键收集的字段列表
+// 参数:
+//   - t: 结构体类型
+//   - tagKey: 标签键，如"json"、"yaml"
+//
+// 返回值:
+//   - []structField: 字段列表，按层级由浅到深排列
+func cachedFields(t reflect.Type, tagKey string) []structField {
+	key := fieldCacheKey{t, tagKey}
+	if f, ok := fieldCache.Load(key); ok {
+		return f.([]structField)
+	}
+	f, _ := fieldCache.LoadOrStore(key, typeFields(t, tagKey))
+	return f.([]structField)
+}
+
+// typeFields 按层级遍历结构体及其嵌入结构体，收集字段
+//
+// 说明：yaml标签只展开带inline选项的字段，其余标签展开未命名的嵌入结构体
+func typeFields(t reflect.Type, tagKey string) []structField {
+	type level struct {
+		t     reflect.Type
+		index []int
+	}
+
+	var fields []structField
+	seen := map[string]bool{}
+	visited := map[reflect.Type]bool{}
+	next := []level{{t: t}}
+	for len(next) > 0 {
+		current := next
+		next = nil
+		names := map[string]bool{}
+		for _, l := range current {
+			if visited[l.t] {
+				continue
+			}
+			visited[l.t] = true
+
+			for i := 0; i < l.t.NumField(); i++ {
+				sf := l.t.Field(i)
+				tag := sf.Tag.Get(tagKey)
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+				index := append(slices.Clone(l.index), i)
+
+				ft := sf.Type
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				inline := tagKey == "yaml" && hasTagOpt(opts, "inline")
+				embedded := tagKey != "yaml" && sf.Anonymous && name == ""
+				if (inline || embedded) && ft.Kind() == reflect.Struct {
+					// 未导出的嵌入指针无法分配，与encoding/json一致忽略
+					if !sf.IsExported() && sf.Type.Kind() == reflect.Pointer {
+						continue
+					}
+					next = append(next, level{ft, index})
+					continue
+				}
+				if !sf.IsExported() {
+					continue
+				}
+
+				if name == "" {
+					name = sf.Name
+					if tagKey == "yaml" {
+						name = strings.ToLower(name)
+					}
+				}
+				if seen[name] {
+					continue
+				}
+				names[name] = true
+				fields = append(fields, structField{name: name, index: index, typ: sf.Type, tag: sf.Tag, opts: opts})
+			}
+		}
+		for name := range names {
+			seen[name] = true
+		}
+	}
+	return fields
+}
+
+// orderedFields 获取按字段在结构体中的位置排列的字段列表，嵌入结构体的字段位于嵌入的位置，用于输出列或键值对
+func orderedFields(t reflect.Type, tagKey string) []structField {
+	fields := slices.Clone(cachedFields(t, tagKey))
+	slices.SortFunc(fields, func(a, b structField) int {
+		return slices.Compare(a.index, b.index)
+	})
+	return fields
+}
+
+// lookupField 按键名查找字段
+// 参数:
+//   - fields: 字段列表
+//   - key: 键名
+//   - fold: 精确匹配失败时是否按不区分大小写匹配（encoding/json的行为）
+func lookupField(fields []structField, key string, fold bool) *structField {
+	for i := range fields {
+		if fields[i].name == key {
+			return &fields[i]
+		}
+	}
+	if fold {
+		for i := range fields {
+			if strings.EqualFold(fields[i].name, key) {
+				return &fields[i]
+			}
+		}
+	}
+	return nil
+}
+
+// fieldByIndex 按索引路径获取字段值，路径上的nil嵌入指针会被自动分配
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
@@ -0,0 +1,450 @@
+/*
+--------------------------------
+@Create 2026/10/18 19:30
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 19:30
+@Description flag.Value支持与结构体到命令行参数的绑定
+--------------------------------
+本文件为各strval类型实现flag.Value接口（Set/String，以及pflag等库使用的Type），
+并提供BindFlags，按结构体字段在flag.FlagSet上注册命令行参数，使同一个配置结构体
+既可以从YAML/JSON加载，也可以由命令行参数覆盖。命令行参数与UnmarshalJSON共用同一套宽松的解析逻辑，
+因此--enabled=yes、--port=" 8080 "等写法同样有效，字段上的strval标签与预处理器也会生效。
+
+字段使用以下标签：
+
+	Port    strval.Int  `yaml:"port" flag:"port" usage:"监听端口" default:"8080"`
+	Enabled strval.Bool `yaml:"enabled" usage:"是否启用"`
+	Secret  string      `flag:"-"`
+
+参数名依次取flag、yaml、json标签中的名称，都没有时使用字段名的短横线形式（MaxCount→max-count）；
+嵌套结构体的参数名以"."连接，如db.port；default标签只在字段为零值时生效，否则以字段当前值作为默认值。
+*/
+
+package strval
+
+import (
+	"encoding"
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// stringSetter 由各strval类型实现，按指定选项从字符串设置值
+type stringSetter interface {
+	setString(s string, o *Options) error
+}
+
+// Set 实现flag.Value接口，按与字符串形式的JSON值相同的规则解析命令行参数
+//
+// 说明：与其他反序列化方法不同，解析失败时返回错误，以便flag包向用户提示
+func (b *Bool) Set(s string) error {
+	return b.setString(s, currentOptions())
+}
+
+// String 实现flag.Value与fmt.Stringer接口
+func (b Bool) String() string {
+	return strconv.FormatBool(bool(b))
+}
+
+// Type 返回参数类型名称，供pflag等库使用
+func (b Bool) Type() string {
+	return "bool"
+}
+
+// IsBoolFlag 使flag包允许省略参数值，如--enabled等价于--enabled=true
+func (b Bool) IsBoolFlag() bool {
+	return true
+}
+
+// Set 实现flag.Value接口，按与字符串形式的JSON值相同的规则解析命令行参数
+//
+// 说明：解析失败时返回错误
+func (i *Int) Set(s string) error {
+	return i.setString(s, currentOptions())
+}
+
+// String 实现flag.Value与fmt.Stringer接口
+func (i Int) String() string {
+	return strconv.Itoa(int(i))
+}
+
+// Type 返回参数类型名称，供pflag等库使用
+func (i Int) Type() string {
+	return "int"
+}
+
+// Set 实现flag.Value接口，按与字符串形式的JSON值相同的规则解析命令行参数
+//
+// 说明：解析失败时返回错误
+func (f *Float) Set(s string) error {
+	return f.setString(s, currentOptions())
+}
+
+// String 实现flag.Value与fmt.Stringer接口
+func (f Float) String() string {
+	return strconv.FormatFloat(float64(f), 'g', -1, 64)
+}
+
+// Type 返回参数类型名称，供pflag等库使用
+func (f Float) Type() string {
+	return "float64"
+}
+
+// Set 实现flag.Value接口，执行为KindString注册的预处理器
+func (s *String) Set(str string) error {
+	return s.setString(str, currentOptions())
+}
+
+// String 实现flag.Value与fmt.Stringer接口
+func (s String) String() string {
+	return string(s)
+}
+
+// Type 返回参数类型名称，供pflag等库使用
+func (s String) Type() string {
+	return "string"
+}
+
+// Set 实现flag.Value接口，空字符串与未知值词汇得到无效值
+//
+// 说明：解析失败时返回错误
+func (n *NullBool) Set(s string) error {
+	return n.setString(s, currentOptions())
+}
+
+// String 实现flag.Value与fmt.Stringer接口，无效值返回空字符串
+func (n NullBool) String() string {
+	if !n.Valid {
+		return ""
+	}
+	return strconv.FormatBool(n.Bool)
+}
+
+// Type 返回参数类型名称，供pflag等库使用
+func (n NullBool) Type() string {
+	return "bool"
+}
+
+// IsBoolFlag 使flag包允许省略参数值
+func (n NullBool) IsBoolFlag() bool {
+	return true
+}
+
+// Set 实现flag.Value接口，解析规则与T相同
+//
+// 说明：命令行参数没有可保留的原始表示，Modified始终返回true
+func (p *Preserved[T]) Set(s string) error {
+	return p.setString(s, currentOptions())
+}
+
+// setString 按指定选项解析字符串并丢弃原始表示，供BindFlags与default标签使用
+func (p *Preserved[T]) setString(s string, o *Options) error {
+	*p = Preserved[T]{}
+	return any(&p.Val).(stringSetter).setString(s, o)
+}
+
+// optionFlag 创建使用指定选项解析参数的flag.Value，供BindFlags使用
+func (p *Preserved[T]) optionFlag(o *Options) flag.Value {
+	return newOptionFlag(p, o)
+}
+
+// String 实现flag.Value与fmt.Stringer接口，输出包装值的字符串形式
+func (p Preserved[T]) String() string {
+	return any(p.Val).(fmt.Stringer).String()
+}
+
+// Type 返回包装值的参数类型名称
+func (p Preserved[T]) Type() string {
+	return any(p.Val).(interface{ Type() string }).Type()
+}
+
+// IsBoolFlag 包装Bool或NullBool时允许省略参数值
+func (p Preserved[T]) IsBoolFlag() bool {
+	_, ok := any(p.Val).(interface{ IsBoolFlag() bool })
+	return ok
+}
+
+// optionFlag 使用指定选项解析参数的flag.Value，由BindFlags创建
+type optionFlag[T any, P interface {
+	*T
+	stringSetter
+	fmt.Stringer
+}] struct {
+	target P
+	o      *Options
+}
+
+// newOptionFlag 创建绑定到target的参数值
+func newOptionFlag[T any, P interface {
+	*T
+	stringSetter
+	fmt.Stringer
+}](target P, o *Options) flag.Value {
+	return &optionFlag[T, P]{target: target, o: o}
+}
+
+// Set 实现flag.Value接口
+func (f *optionFlag[T, P]) Set(s string) error {
+	return f.target.setString(s, f.o)
+}
+
+// String 实现flag.Value接口
+//
+// 说明：flag包会创建零值并调用String以判断默认值是否为零值，此时输出T零值的字符串形式
+func (f *optionFlag[T, P]) String() string {
+	if f.target == nil {
+		var zero T
+		return P(&zero).String()
+	}
+	return f.target.String()
+}
+
+// IsBoolFlag 布尔类型允许省略参数值
+func (f *optionFlag[T, P]) IsBoolFlag() bool {
+	var zero T
+	b, ok := any(&zero).(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+var (
+	stringSetterType = reflect.TypeFor[stringSetter]()
+	flagValueType    = reflect.TypeFor[flag.Value]()
+	durationType     = reflect.TypeFor[time.Duration]()
+)
+
+// BindFlags 按结构体字段在FlagSet上注册命令行参数
+// 参数:
+//   - fs: 注册参数的FlagSet，如flag.CommandLine
+//   - v: 结构体指针
+//   - opts: strval字段解析参数时使用的单次调用选项
+//
+// 返回值:
+//   - error: v不是结构体指针、default标签无法解析或字段类型不支持时返回错误
+//
+// 说明:
+//   - strval类型（包括Preserved）按宽松的规则解析，字段上的strval标签同样生效
+//   - 支持string、bool、各种整数与浮点数、time.Duration以及实现了flag.Value的类型
+//   - 不支持的字段类型需要使用flag:"-"忽略
+func BindFlags(fs *flag.FlagSet, v any, opts ...Option) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("strval: BindFlags requires a non-nil struct pointer, got %T", v)
+	}
+	return bindStruct(fs, rv.Elem(), "", resolveOptions(opts))
+}
+
+// bindStruct 注册结构体各字段对应的参数
+func bindStruct(fs *flag.FlagSet, v reflect.Value, prefix string, o *Options) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, ok := flagName(sf)
+		if !ok || (!sf.IsExported() && !sf.Anonymous) {
+			continue
+		}
+		fv := v.Field(i)
+
+		if fv.Kind() == reflect.Pointer && fv.Type().Elem().Kind() == reflect.Struct && !isFlagValue(fv.Type()) {
+			if !sf.IsExported() {
+				// 未导出的嵌入指针无法分配
+				continue
+			}
+			if fv.IsNil() {
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct && !isFlagValue(fv.Addr().Type()) {
+			sub := prefix
+			if !sf.Anonymous || hasFlagTag(sf) {
+				sub = prefix + name + "."
+			}
+			if err := bindStruct(fs, fv, sub, o); err != nil {
+				return err
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		fo, err := fieldOptions(o, sf.Tag)
+		if err != nil {
+			return err
+		}
+		if err := bindField(fs, fv, prefix+name, sf.Tag, fo); err != nil {
+			return fmt.Errorf("strval: field %s: %w", sf.Name, err)
+		}
+	}
+	return nil
+}
+
+// bindField 注册单个字段对应的参数
+func bindField(fs *flag.FlagSet, fv reflect.Value, name string, tag reflect.StructTag, o *Options) error {
+	usage := tag.Get("usage")
+	if def, ok := tag.Lookup("default"); ok && fv.IsZero() {
+		if err := setFieldString(fv, def, o); err != nil {
+			return fmt.Errorf("invalid default %q: %w", def, err)
+		}
+	}
+
+	ptr := fv.Addr()
+	if value := strvalFlag(ptr.Interface(), o); value != nil {
+		fs.Var(value, name, usage)
+		return nil
+	}
+	if value, ok := ptr.Interface().(flag.Value); ok {
+		fs.Var(value, name, usage)
+		return nil
+	}
+
+	switch p := ptr.Interface().(type) {
+	case *string:
+		fs.StringVar(p, name, *p, usage)
+	case *bool:
+		fs.BoolVar(p, name, *p, usage)
+	case *int:
+		fs.IntVar(p, name, *p, usage)
+	case *int64:
+		fs.Int64Var(p, name, *p, usage)
+	case *uint:
+		fs.UintVar(p, name, *p, usage)
+	case *uint64:
+		fs.Uint64Var(p, name, *p, usage)
+	case *float64:
+		fs.Float64Var(p, name, *p, usage)
+	case *time.Duration:
+		fs.DurationVar(p, name, *p, usage)
+	default:
+		return fmt.Errorf("unsupported flag type %s", fv.Type())
+	}
+	return nil
+}
+
+// strvalFlag 为strval类型的字段创建使用指定选项的参数值，其他类型返回nil
+func strvalFlag(ptr any, o *Options) flag.Value {
+	switch p := ptr.(type) {
+	case *Bool:
+		return newOptionFlag(p, o)
+	case *Int:
+		return newOptionFlag(p, o)
+	case *Float:
+		return newOptionFlag(p, o)
+	case *String:
+		return newOptionFlag(p, o)
+	case *NullBool:
+		return newOptionFlag(p, o)
+	case interface{ optionFlag(o *Options) flag.Value }:
+		// Preserved[T]
+		return p.optionFlag(o)
+	}
+	return nil
+}
+
+// setFieldString 按字段类型从字符串设置字段值，用于default标签与环境变量
+func setFieldString(fv reflect.Value, s string, o *Options) error {
+	ptr := fv.Addr()
+	if setter, ok := ptr.Interface().(stringSetter); ok {
+		return setter.setString(s, o)
+	}
+	if value, ok := ptr.Interface().(flag.Value); ok {
+		return value.Set(s)
+	}
+	if u, ok := ptr.Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	if fv.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 0, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 0, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+	return nil
+}
+
+// isFlagValue 判断类型是否可以直接作为参数值
+func isFlagValue(t reflect.Type) bool {
+	return t.Implements(stringSetterType) || t.Implements(flagValueType)
+}
+
+// hasFlagTag 判断字段是否带有flag标签
+func hasFlagTag(sf reflect.StructField) bool {
+	_, ok := sf.Tag.Lookup("flag")
+	return ok
+}
+
+// flagName 返回字段对应的参数名，flag:"-"时返回false
+func flagName(sf reflect.StructField) (string, bool) {
+	for _, key := range []string{"flag", "yaml", "json"} {
+		tag, ok := sf.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			// yaml/json中忽略的字段仍可以通过flag标签绑定
+			if key == "flag" {
+				return "", false
+			}
+			continue
+		}
+		if name != "" {
+			return name, true
+		}
+	}
+	return kebabCase(sf.Name), true
+}
+
+// kebabCase 将字段名转换为短横线形式，如MaxCount→max-count、HTTPPort→http-port
+func kebabCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
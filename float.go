@@ -0,0 +1,111 @@
+/*
+--------------------------------
+@Create 2026/10/18 09:30
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 09:30
+@Description 浮点数解析辅助函数
+--------------------------------
+本文件实现了Float类型共用的解析与非有限值（NaN/±Inf）处理逻辑，
+JSON、YAML与数据库路径均通过这里的函数保持一致的行为。
+*/
+
+package strval
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// errNonFinite 非有限值被策略拒绝时返回的错误
+var errNonFinite = errors.New("non-finite float value not allowed")
+
+// parseFloat 解析字符串形式的浮点数
+// 参数:
+//   - input: 输入字符串
+//   - o: 解析选项
+//
+// 返回值:
+//   - float64: 解析后的浮点数
+//   - error: 解析过程中的错误
+//
+// 说明：除strconv.ParseFloat支持的形式外，还支持首尾空白、YAML的.nan、.inf、-.inf形式，
+// Options.NumberFormats中配置的本地化格式，以及Options.Unicode启用的全角字符与中文数字
+// input可能引用调用方的缓冲区（见bytesView），错误中保存的是它的副本
+func parseFloat(input string, o *Options) (float64, error) {
+	s, chinese, err := normalizeNumberUnicode(input, o)
+	if err != nil {
+		return 0, &strconv.NumError{Func: "ParseFloat", Num: strings.Clone(input), Err: err}
+	}
+	if chinese != nil {
+		v, _ := chinese.Float64()
+		return v, nil
+	}
+
+	// 与parseInt一致忽略首尾空白
+	s = strings.TrimSpace(s)
+	v, ok := parseYAMLNonFinite(s)
+	if !ok {
+		if v, ok, err = parseLocalized(s, o, parseCanonicalFloat); ok && err != nil {
+			return 0, &strconv.NumError{Func: "ParseFloat", Num: strings.Clone(input), Err: err}
+		}
+		if !ok {
+			if v, err = strconv.ParseFloat(s, 64); err != nil {
+				if numErr, ok := err.(*strconv.NumError); ok {
+					numErr.Num = strings.Clone(input)
+				}
+				return 0, err
+			}
+		}
+	}
+	if err := checkFinite(v, o); err != nil {
+		return 0, err
+	}
+	return v, nil
+}
+
+// parseCanonicalFloat 解析本地化格式规范化后的浮点数
+func parseCanonicalFloat(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64)
+}
+
+// parseYAMLNonFinite 解析YAML形式的非有限值（.nan、.inf、+.inf、-.inf，不区分大小写）
+func parseYAMLNonFinite(s string) (float64, bool) {
+	switch strings.ToLower(s) {
+	case ".nan":
+		return math.NaN(), true
+	case ".inf", "+.inf":
+		return math.Inf(1), true
+	case "-.inf":
+		return math.Inf(-1), true
+	default:
+		return 0, false
+	}
+}
+
+// checkFinite 根据输入策略检查浮点数是否允许为非有限值
+func checkFinite(v float64, o *Options) error {
+	if o.NonFiniteInput == NonFiniteInputReject && isNonFinite(v) {
+		return errNonFinite
+	}
+	return nil
+}
+
+// isNonFinite 判断浮点数是否为NaN或±Inf
+func isNonFinite(v float64) bool {
+	return math.IsNaN(v) || math.IsInf(v, 0)
+}
+
+// nonFiniteString 返回非有限值的字符串表示："NaN"、"Infinity"或"-Infinity"
+func nonFiniteString(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case v > 0:
+		return "Infinity"
+	default:
+		return "-Infinity"
+	}
+}
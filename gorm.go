@@ -0,0 +1,82 @@
+/*
+--------------------------------
+@Create 2026/10/19 03:40
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 03:40
+@Description GORM的列类型接口
+--------------------------------
+GORM在AutoMigrate时通过GormDataType() string获取字段的数据类型，未实现时按底层类型推断，
+ColumnBool、Preserved等结构体类型因此无法建表。本文件为各strval类型实现该方法：
+1. 返回GORM的通用类型名（bool、int、float、string），由各方言按size、precision、scale等标签选择具体的列类型，
+   如MySQL中`gorm:"precision:10;scale:2"`的Float得到decimal(10,2)，`gorm:"size:32"`的String得到varchar(32)
+2. 以字符对存储的布尔值（ColumnBool/ColumnNullBool或全局选项BoolColumn）返回char(n)，以整数存储的返回smallint
+
+使用构建标签strval_gorm编译时，建表时GORM优先使用按方言选择列类型的GormDBDataType，见gormdb.go；本文件不依赖GORM，默认构建即生效。
+*/
+
+package strval
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// GORM的通用数据类型名，与gorm.io/gorm/schema中的DataType常量一致
+const (
+	gormBool   = "bool"
+	gormInt    = "int"
+	gormFloat  = "float"
+	gormString = "string"
+)
+
+// GormDataType 实现GORM的GormDataTypeInterface接口，默认返回通用类型bool，全局选项BoolColumn非零值时与其表示形式一致
+func (Bool) GormDataType() string { return currentOptions().BoolColumn.dataType() }
+
+// GormDataType 实现GORM的GormDataTypeInterface接口，默认返回通用类型bool，全局选项BoolColumn非零值时与其表示形式一致
+func (NullBool) GormDataType() string { return currentOptions().BoolColumn.dataType() }
+
+// GormDataType 实现GORM的GormDataTypeInterface接口，返回通用类型int，位数由size标签指定
+func (Int) GormDataType() string { return gormInt }
+
+// GormDataType 实现GORM的GormDataTypeInterface接口，返回通用类型float，设置precision标签时各方言使用定点数类型
+func (Float) GormDataType() string { return gormFloat }
+
+// GormDataType 实现GORM的GormDataTypeInterface接口，返回通用类型string，长度由size标签指定
+func (String) GormDataType() string { return gormString }
+
+// GormDataType 实现GORM的GormDataTypeInterface接口，按C的表示形式返回列类型
+func (ColumnBool[C]) GormDataType() string {
+	var c C
+	return c.BoolColumn().dataType()
+}
+
+// GormDataType 实现GORM的GormDataTypeInterface接口，按C的表示形式返回列类型
+func (ColumnNullBool[C]) GormDataType() string {
+	var c C
+	return c.BoolColumn().dataType()
+}
+
+// GormDataType 实现GORM的GormDataTypeInterface接口，与T的列类型相同
+func (p Preserved[T]) GormDataType() string {
+	return any(p.Val).(interface{ GormDataType() string }).GormDataType()
+}
+
+// dataType 返回该表示形式对应的GORM数据类型
+//
+// 说明：字符对按较长的一方的字符数确定长度，两者长度不同时使用varchar避免CHAR(n)补齐空格
+func (c BoolColumn) dataType() string {
+	switch c.Kind {
+	case BoolColumnInt:
+		return "smallint"
+	case BoolColumnChars:
+		t, f := utf8.RuneCountInString(c.True), utf8.RuneCountInString(c.False)
+		n := max(t, f, 1)
+		if t != f {
+			return fmt.Sprintf("varchar(%d)", n)
+		}
+		return fmt.Sprintf("char(%d)", n)
+	default:
+		return gormBool
+	}
+}
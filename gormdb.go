@@ -0,0 +1,213 @@
+//go:build strval_gorm
+
+/*
+--------------------------------
+@Create 2026/10/19 04:10
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 04:10
+@Description 按数据库方言选择GORM列类型
+--------------------------------
+GORM的Migrator在建表时优先调用GormDBDataType(*gorm.DB, *schema.Field)，返回空字符串时才交给方言的DataTypeOf。
+本文件为各strval类型实现该方法，按方言与字段的size、precision、scale标签选择列类型：
+1. 支持mysql、postgres、sqlite与sqlserver，其余方言返回空字符串，由方言按GormDataType返回的通用类型选择
+2. Int按size选择整数宽度，Float设置precision时使用定点数，String设置size时使用变长字符串
+3. 以整数或字符对存储的布尔值使用各方言中对应的整数或定长字符类型
+
+本文件依赖gorm.io/gorm，只在使用构建标签strval_gorm编译时参与编译，默认构建不引入GORM依赖，各类型仍然实现不依赖GORM的GormDataType。
+使用该标签的模块需要自行依赖gorm.io/gorm。
+*/
+
+package strval
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// gormDialect 返回数据库连接使用的方言名称，未初始化时返回空字符串
+func gormDialect(db *gorm.DB) string {
+	if db == nil || db.Config == nil || db.Dialector == nil {
+		return ""
+	}
+	return db.Dialector.Name()
+}
+
+// gormField 返回字段的结构信息，为nil时按未设置标签处理
+func gormField(field *schema.Field) *schema.Field {
+	if field == nil {
+		return &schema.Field{}
+	}
+	return field
+}
+
+// GormDBDataType 实现GORM的Migrator所使用的GormDataTypeInterface接口，按方言返回列类型
+// 参数:
+//   - db: 数据库连接，用于获取方言
+//   - field: 字段的结构信息
+//
+// 返回值:
+//   - string: 列类型，为空时由方言决定
+func (Bool) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return currentOptions().BoolColumn.dbDataType(gormDialect(db))
+}
+
+// GormDBDataType 实现GORM的Migrator所使用的GormDataTypeInterface接口，按方言返回列类型
+func (NullBool) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return currentOptions().BoolColumn.dbDataType(gormDialect(db))
+}
+
+// GormDBDataType 实现GORM的Migrator所使用的GormDataTypeInterface接口，按方言与C的表示形式返回列类型
+func (ColumnBool[C]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	var c C
+	return c.BoolColumn().dbDataType(gormDialect(db))
+}
+
+// GormDBDataType 实现GORM的Migrator所使用的GormDataTypeInterface接口，按方言与C的表示形式返回列类型
+func (ColumnNullBool[C]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	var c C
+	return c.BoolColumn().dbDataType(gormDialect(db))
+}
+
+// GormDBDataType 实现GORM的Migrator所使用的GormDataTypeInterface接口，按方言与size标签返回整数类型
+//
+// 说明：未设置size时为64位整数
+func (Int) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	size := gormField(field).Size
+	if size <= 0 || size > 64 {
+		size = 64
+	}
+	switch gormDialect(db) {
+	case "mysql":
+		switch {
+		case size <= 8:
+			return "tinyint"
+		case size <= 16:
+			return "smallint"
+		case size <= 24:
+			return "mediumint"
+		case size <= 32:
+			return "int"
+		}
+		return "bigint"
+	case "postgres":
+		switch {
+		case size <= 16:
+			return "smallint"
+		case size <= 32:
+			return "integer"
+		}
+		return "bigint"
+	case "sqlserver":
+		// sqlserver的tinyint为无符号整数，8位整数同样使用smallint
+		switch {
+		case size <= 16:
+			return "smallint"
+		case size <= 32:
+			return "int"
+		}
+		return "bigint"
+	case "sqlite":
+		return "integer"
+	}
+	return ""
+}
+
+// GormDBDataType 实现GORM的Migrator所使用的GormDataTypeInterface接口，按方言与precision、scale、size标签返回浮点数类型
+//
+// 说明：设置precision时使用定点数，如`gorm:"precision:10;scale:2"`在MySQL中为decimal(10,2)；
+// 否则size不超过32时使用单精度浮点数，默认为双精度浮点数
+func (Float) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	f, dialect := gormField(field), gormDialect(db)
+	if f.Precision > 0 {
+		switch dialect {
+		case "mysql", "sqlserver":
+			return fmt.Sprintf("decimal(%d,%d)", f.Precision, f.Scale)
+		case "postgres", "sqlite":
+			return fmt.Sprintf("numeric(%d,%d)", f.Precision, f.Scale)
+		}
+		return ""
+	}
+	single := f.Size > 0 && f.Size <= 32
+	switch dialect {
+	case "mysql":
+		if single {
+			return "float"
+		}
+		return "double"
+	case "postgres":
+		if single {
+			return "real"
+		}
+		return "double precision"
+	case "sqlserver":
+		if single {
+			return "real"
+		}
+		return "float"
+	case "sqlite":
+		return "real"
+	}
+	return ""
+}
+
+// GormDBDataType 实现GORM的Migrator所使用的GormDataTypeInterface接口，按方言与size标签返回字符串类型
+//
+// 说明：设置size时使用变长字符串；未设置时postgres与sqlite使用text，sqlserver使用nvarchar(MAX)，
+// mysql返回空字符串，由方言按DefaultStringSize等配置决定
+func (String) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	size := gormField(field).Size
+	switch gormDialect(db) {
+	case "mysql":
+		switch {
+		case size <= 0:
+			return ""
+		case size < 65536:
+			return fmt.Sprintf("varchar(%d)", size)
+		case size < 1<<24:
+			return "mediumtext"
+		}
+		return "longtext"
+	case "postgres":
+		if size > 0 {
+			return fmt.Sprintf("varchar(%d)", size)
+		}
+		return "text"
+	case "sqlserver":
+		if size > 0 && size <= 4000 {
+			return fmt.Sprintf("nvarchar(%d)", size)
+		}
+		return "nvarchar(MAX)"
+	case "sqlite":
+		return "text"
+	}
+	return ""
+}
+
+// GormDBDataType 实现GORM的Migrator所使用的GormDataTypeInterface接口，与T的列类型相同
+func (p Preserved[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return any(p.Val).(interface {
+		GormDBDataType(*gorm.DB, *schema.Field) string
+	}).GormDBDataType(db, field)
+}
+
+// dbDataType 返回该表示形式在指定方言中的列类型
+//
+// 说明：原生bool返回空字符串，由方言决定（如MySQL的boolean、sqlserver的bit）；
+// 其余方言与dataType相同，sqlserver的字符对使用nchar/nvarchar
+func (c BoolColumn) dbDataType(dialect string) string {
+	if c.Kind == BoolColumnNative || dialect == "" {
+		return ""
+	}
+	switch {
+	case c.Kind == BoolColumnInt && dialect == "mysql":
+		return "tinyint(1)"
+	case c.Kind == BoolColumnInt && dialect == "oracle":
+		return "number(1)"
+	case c.Kind == BoolColumnChars && dialect == "sqlserver":
+		return "n" + c.dataType()
+	}
+	return c.dataType()
+}
@@ -0,0 +1,371 @@
+/*
+--------------------------------
+@Create 2026/10/18 20:50
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 20:50
+@Description HTTP请求参数绑定与RFC 7807错误响应
+--------------------------------
+本文件提供BindRequest，按结构体标签从HTTP请求的路径参数、查询参数、表单（含multipart）、
+请求头与Cookie中读取参数，strval类型的字段使用与UnmarshalJSON中字符串相同的宽松解析逻辑，
+因此?active=yes&limit=20可以直接绑定到strval.Bool与strval.Int字段。
+所有无效或缺失的参数汇总为一个错误返回，可以使用WriteProblem输出application/problem+json响应。
+
+字段使用以下标签，同一字段带有多个标签时按path、query、form、header、cookie的顺序取第一个存在的值：
+
+	ID     strval.Int            `path:"id"`
+	Active strval.Bool           `query:"active" default:"true"`
+	Limit  strval.Int            `query:"limit,required"`
+	Tags   []string              `query:"tag"`
+	Name   strval.String         `form:"name"`
+	Avatar *multipart.FileHeader `form:"avatar"`
+	Token  string                `header:"X-Token"`
+	Lang   string                `cookie:"lang"`
+*/
+
+package strval
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// DefaultMaxMemory BindRequest解析multipart表单时保存在内存中的最大字节数，超出部分写入临时文件
+const DefaultMaxMemory = 32 << 20
+
+// bindSources 参数来源的标签，按优先级排列
+var bindSources = []string{"path", "query", "form", "header", "cookie"}
+
+var fileHeaderType = reflect.TypeFor[*multipart.FileHeader]()
+
+// BindError 请求参数错误，BindRequest返回的汇总错误由若干BindError组成，可使用errors.As获取
+type BindError struct {
+	// In 参数来源，为path、query、form、header或cookie
+	In string
+	// Name 参数名
+	Name string
+	// Value 参数值，参数缺失时为空
+	Value string
+	// Err 具体的错误原因
+	Err error
+}
+
+// Error 实现error接口
+func (e *BindError) Error() string {
+	return fmt.Sprintf("strval: %s parameter %s: %v", e.In, e.Name, e.Err)
+}
+
+// Unwrap 返回具体的错误原因
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+// errBindRequired 必需的参数不存在
+var errBindRequired = errors.New("required parameter is missing")
+
+// BindRequest 按结构体标签从HTTP请求中读取参数
+// 参数:
+//   - r: HTTP请求
+//   - v: 结构体指针
+//   - opts: strval字段解析时使用的单次调用选项
+//
+// 返回值:
+//   - error: 表单解析错误，或所有无效、缺失的参数汇总后的错误，后者每一项为*BindError
+//
+// 说明:
+//   - 存在form标签时才会解析请求体，multipart表单使用DefaultMaxMemory解析
+//   - 切片字段接收同名的多个值，如?tag=a&tag=b
+//   - form标签可以绑定*multipart.FileHeader与[]*multipart.FileHeader类型的上传文件
+func BindRequest(r *http.Request, v any, opts ...Option) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("strval: BindRequest requires a non-nil struct pointer, got %T", v)
+	}
+	b := requestBinder{r: r, query: r.URL.Query()}
+	if usesForm(rv.Elem().Type()) {
+		if err := parseForm(r); err != nil {
+			return fmt.Errorf("strval: parse form: %w", err)
+		}
+	}
+	b.bindStruct(rv.Elem(), resolveOptions(opts))
+	return errors.Join(b.errs...)
+}
+
+// parseForm 按Content-Type解析普通表单或multipart表单
+func parseForm(r *http.Request) error {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		return r.ParseMultipartForm(DefaultMaxMemory)
+	}
+	return r.ParseForm()
+}
+
+// usesForm 判断结构体中是否存在form标签
+func usesForm(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if _, ok := sf.Tag.Lookup("form"); ok {
+			return true
+		}
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && !isEnvValue(reflect.PointerTo(ft)) && usesForm(ft) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestBinder 绑定请求参数时的状态
+type requestBinder struct {
+	r *http.Request
+	// query 解析后的查询参数，每次BindRequest只解析一次
+	query url.Values
+	errs  []error
+}
+
+// bindStruct 绑定结构体的各字段，嵌套结构体展开到同一层
+func (b *requestBinder) bindStruct(v reflect.Value, o *Options) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+		fv := v.Field(i)
+		in, name := bindTag(sf)
+
+		if in == "" {
+			if fv.Kind() == reflect.Pointer && fv.Type().Elem().Kind() == reflect.Struct && !isEnvValue(fv.Type()) {
+				if !sf.IsExported() {
+					continue
+				}
+				if fv.IsNil() {
+					fv.Set(reflect.New(fv.Type().Elem()))
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct && !isEnvValue(fv.Addr().Type()) {
+				b.bindStruct(fv, o)
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		fo, err := fieldOptions(o, sf.Tag)
+		if err != nil {
+			b.errs = append(b.errs, &BindError{In: in, Name: name, Err: err})
+			continue
+		}
+		b.bindField(fv, sf, fo)
+	}
+}
+
+// bindField 绑定单个字段，按标签的优先级查找参数值
+func (b *requestBinder) bindField(fv reflect.Value, sf reflect.StructField, o *Options) {
+	required := false
+	for _, in := range bindSources {
+		tag, ok := sf.Tag.Lookup(in)
+		if !ok {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = sf.Name
+		}
+		required = required || hasTagOpt(opts, "required")
+
+		if in == "form" && b.bindFiles(fv, name) {
+			return
+		}
+		values := b.lookup(in, name)
+		if len(values) == 0 {
+			continue
+		}
+		if err := setFieldValues(fv, values, o); err != nil {
+			b.errs = append(b.errs, &BindError{In: in, Name: name, Value: strings.Join(values, ","), Err: err})
+		}
+		return
+	}
+
+	in, name := bindTag(sf)
+	if def, ok := sf.Tag.Lookup("default"); ok {
+		if fv.IsZero() {
+			if err := setFieldValues(fv, []string{def}, o); err != nil {
+				b.errs = append(b.errs, &BindError{In: in, Name: name, Value: def, Err: fmt.Errorf("invalid default: %w", err)})
+			}
+		}
+		return
+	}
+	if required {
+		b.errs = append(b.errs, &BindError{In: in, Name: name, Err: errBindRequired})
+	}
+}
+
+// lookup 返回指定来源中的参数值
+func (b *requestBinder) lookup(in, name string) []string {
+	switch in {
+	case "path":
+		if value := b.r.PathValue(name); value != "" {
+			return []string{value}
+		}
+	case "query":
+		return b.query[name]
+	case "form":
+		return b.r.PostForm[name]
+	case "header":
+		return b.r.Header.Values(name)
+	case "cookie":
+		if c, err := b.r.Cookie(name); err == nil {
+			return []string{c.Value}
+		}
+	}
+	return nil
+}
+
+// bindFiles 绑定上传文件，字段不是文件类型时返回false
+func (b *requestBinder) bindFiles(fv reflect.Value, name string) bool {
+	isFile := fv.Type() == fileHeaderType
+	isFiles := fv.Kind() == reflect.Slice && fv.Type().Elem() == fileHeaderType
+	if !isFile && !isFiles {
+		return false
+	}
+	if b.r.MultipartForm == nil || len(b.r.MultipartForm.File[name]) == 0 {
+		return false
+	}
+	files := b.r.MultipartForm.File[name]
+	if isFile {
+		fv.Set(reflect.ValueOf(files[0]))
+	} else {
+		fv.Set(reflect.ValueOf(files))
+	}
+	return true
+}
+
+// setFieldValues 从一个或多个字符串设置字段值，切片字段接收所有值，其他字段使用第一个值
+func setFieldValues(fv reflect.Value, values []string, o *Options) error {
+	if fv.Kind() == reflect.Slice && !isEnvValue(fv.Addr().Type()) && fv.Type().Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(fv.Type(), len(values), len(values))
+		var errs []error
+		for i, value := range values {
+			if err := setFieldString(slice.Index(i), value, o); err != nil {
+				errs = append(errs, fmt.Errorf("value %d: %w", i, err))
+			}
+		}
+		fv.Set(slice)
+		return errors.Join(errs...)
+	}
+	return setFieldString(fv, values[0], o)
+}
+
+// bindTag 返回字段优先级最高的参数来源与参数名，没有参数标签时返回空字符串
+func bindTag(sf reflect.StructField) (in, name string) {
+	for _, in := range bindSources {
+		if tag, ok := sf.Tag.Lookup(in); ok {
+			name, _, _ := strings.Cut(tag, ",")
+			if name == "" {
+				name = sf.Name
+			}
+			return in, name
+		}
+	}
+	return "", ""
+}
+
+// Problem RFC 7807定义的application/problem+json错误响应
+type Problem struct {
+	// Type 错误类型的URI，默认为about:blank
+	Type string `json:"type"`
+	// Title 错误的简短描述，默认为HTTP状态码对应的文本
+	Title string `json:"title"`
+	// Status HTTP状态码
+	Status int `json:"status"`
+	// Detail 错误的详细说明
+	Detail string `json:"detail,omitempty"`
+	// Instance 发生错误的请求URI
+	Instance string `json:"instance,omitempty"`
+	// InvalidParams 无效的参数列表
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// InvalidParam Problem中的单个无效参数
+type InvalidParam struct {
+	// Name 参数名
+	Name string `json:"name"`
+	// In 参数来源，为path、query、form、header或cookie
+	In string `json:"in,omitempty"`
+	// Reason 无效的原因
+	Reason string `json:"reason"`
+}
+
+// NewProblem 根据错误创建Problem
+// 参数:
+//   - status: HTTP状态码，如http.StatusBadRequest
+//   - err: BindRequest返回的错误或其他错误
+//
+// 返回值:
+//   - *Problem: 错误中的每个*BindError对应InvalidParams中的一项，其他错误的内容写入Detail
+func NewProblem(status int, err error) *Problem {
+	p := &Problem{Type: "about:blank", Title: http.StatusText(status), Status: status}
+	var details []string
+	for _, e := range flattenErrors(err) {
+		var be *BindError
+		if errors.As(e, &be) {
+			p.InvalidParams = append(p.InvalidParams, InvalidParam{Name: be.Name, In: be.In, Reason: be.Err.Error()})
+			continue
+		}
+		details = append(details, e.Error())
+	}
+	if len(details) > 0 {
+		p.Detail = strings.Join(details, "; ")
+	} else if len(p.InvalidParams) > 0 {
+		p.Detail = "request parameters are invalid"
+	}
+	return p
+}
+
+// Write 将Problem以application/problem+json格式写入响应
+func (p *Problem) Write(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	return json.NewEncoder(w).Encode(p)
+}
+
+// WriteProblem 根据错误写入application/problem+json响应，等价于NewProblem(status, err).Write(w)
+//
+// 示例:
+//
+//	if err := strval.BindRequest(r, &req); err != nil {
+//		strval.WriteProblem(w, http.StatusBadRequest, err)
+//		return
+//	}
+func WriteProblem(w http.ResponseWriter, status int, err error) error {
+	return NewProblem(status, err).Write(w)
+}
+
+// flattenErrors 展开errors.Join组合的错误
+func flattenErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var errs []error
+		for _, e := range joined.Unwrap() {
+			errs = append(errs, flattenErrors(e)...)
+		}
+		return errs
+	}
+	return []error{err}
+}
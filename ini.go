@@ -0,0 +1,359 @@
+/*
+--------------------------------
+@Create 2026/10/18 23:30
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 23:30
+@Description INI与Java .properties文件解析
+--------------------------------
+本文件提供INI与.properties两种遗留配置格式的解析，仅依赖标准库：
+1. INI：[section]分节，key=value或key: value，;与#开头的行为注释，值两侧的双引号会被去除
+2. .properties：key=value、key: value或key value，#与!开头的行为注释，行尾的\表示续行，
+   支持\t、\n、\uXXXX等转义
+
+节名与键名按"."拆分后映射到嵌套的结构体字段，如[pool]下的size与pool.size都对应Pool.Size；
+字段名按INI的ini标签、.properties的properties标签匹配，没有标签时使用字段名，均不区分大小写；
+string为键的映射字段接收剩余的键。值使用与UnmarshalJSON中字符串相同的宽松解析逻辑，
+所有无效的值以带行号的LineError汇总返回。
+*/
+
+package strval
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// LineError 配置文件中某一行的错误，DecodeINI与DecodeProperties返回的错误由若干LineError组成，可使用errors.As获取
+type LineError struct {
+	// File 文件名，从io.Reader解析时为空
+	File string
+	// Line 行号，从1开始
+	Line int
+	// Key 完整的键名，语法错误时为空
+	Key string
+	// Value 值
+	Value string
+	// Err 具体的错误原因
+	Err error
+}
+
+// Error 实现error接口
+func (e *LineError) Error() string {
+	pos := "line " + strconv.Itoa(e.Line)
+	if e.File != "" {
+		pos = e.File + ":" + strconv.Itoa(e.Line)
+	}
+	if e.Key == "" {
+		return fmt.Sprintf("strval: %s: %v", pos, e.Err)
+	}
+	return fmt.Sprintf("strval: %s: %s: %v", pos, e.Key, e.Err)
+}
+
+// Unwrap 返回具体的错误原因
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// configEntry 配置文件中的一个键值对
+type configEntry struct {
+	path  []string
+	value string
+	line  int
+}
+
+// DecodeINI 解析INI格式的配置并赋值给结构体
+// 参数:
+//   - r: INI内容
+//   - v: 结构体指针
+//   - opts: 值解析时使用的单次调用选项
+//
+// 返回值:
+//   - error: 语法错误时立即返回；所有无效的值汇总后返回，每一项为*LineError
+//
+// 说明：结构体中没有对应字段的键被忽略，重复的键以最后一个为准，空值解析为零值
+func DecodeINI(r io.Reader, v any, opts ...Option) error {
+	return decodeConfig(r, "", "ini", parseINI, v, opts)
+}
+
+// DecodeINIFile 读取并解析INI文件，错误中包含文件名
+func DecodeINIFile(path string, v any, opts ...Option) error {
+	return decodeConfigFile(path, "ini", parseINI, v, opts)
+}
+
+// DecodeProperties 解析Java .properties格式的配置并赋值给结构体
+// 参数:
+//   - r: .properties内容
+//   - v: 结构体指针
+//   - opts: 值解析时使用的单次调用选项
+//
+// 返回值:
+//   - error: 语法错误时立即返回；所有无效的值汇总后返回，每一项为*LineError
+func DecodeProperties(r io.Reader, v any, opts ...Option) error {
+	return decodeConfig(r, "", "properties", parseProperties, v, opts)
+}
+
+// DecodePropertiesFile 读取并解析.properties文件，错误中包含文件名
+func DecodePropertiesFile(path string, v any, opts ...Option) error {
+	return decodeConfigFile(path, "properties", parseProperties, v, opts)
+}
+
+// decodeConfigFile 打开文件并解析
+func decodeConfigFile(path, tagKey string, parse func(io.Reader) ([]configEntry, error), v any, opts []Option) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return decodeConfig(f, path, tagKey, parse, v, opts)
+}
+
+// decodeConfig 解析配置并按键路径赋值给结构体字段
+func decodeConfig(r io.Reader, file, tagKey string, parse func(io.Reader) ([]configEntry, error), v any, opts []Option) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("strval: %s decoding requires a non-nil struct pointer, got %T", tagKey, v)
+	}
+	entries, err := parse(r)
+	if err != nil {
+		var lineErr *LineError
+		if errors.As(err, &lineErr) {
+			lineErr.File = file
+		}
+		return err
+	}
+
+	o := resolveOptions(opts)
+	var errs []error
+	for _, e := range entries {
+		if err := setConfigPath(rv.Elem(), e.path, e.value, tagKey, o); err != nil {
+			errs = append(errs, &LineError{File: file, Line: e.line, Key: strings.Join(e.path, "."), Value: e.value, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// setConfigPath 沿键路径查找字段并赋值，没有对应字段时忽略
+//
+// 说明：每一层优先匹配最长的键名，使pool.size既可以对应嵌套的Pool.Size，也可以对应标签为pool.size的字段
+func setConfigPath(v reflect.Value, path []string, value, tagKey string, o *Options) error {
+	fields := cachedFields(v.Type(), tagKey)
+	for k := len(path); k > 0; k-- {
+		f := lookupField(fields, strings.Join(path[:k], "."), true)
+		if f == nil {
+			continue
+		}
+		fo, err := fieldOptions(o, f.tag)
+		if err != nil {
+			return err
+		}
+		fv := fieldByIndex(v, f.index)
+		rest := path[k:]
+		if len(rest) == 0 {
+			if value == "" {
+				fv.SetZero()
+				return nil
+			}
+			return setFieldText(fv, value, fo)
+		}
+
+		ft := fv.Type()
+		if ft.Kind() == reflect.Pointer && ft.Elem().Kind() == reflect.Struct && !isEnvValue(ft) {
+			if fv.IsNil() {
+				fv.Set(reflect.New(ft.Elem()))
+			}
+			fv = fv.Elem()
+		}
+		switch {
+		case fv.Kind() == reflect.Struct && !isEnvValue(fv.Addr().Type()):
+			return setConfigPath(fv, rest, value, tagKey, fo)
+		case fv.Kind() == reflect.Map && fv.Type().Key().Kind() == reflect.String:
+			if fv.IsNil() {
+				fv.Set(reflect.MakeMap(fv.Type()))
+			}
+			elem := reflect.New(fv.Type().Elem()).Elem()
+			if value != "" {
+				if err := setFieldText(elem, value, fo); err != nil {
+					return err
+				}
+			}
+			fv.SetMapIndex(reflect.ValueOf(strings.Join(rest, ".")).Convert(fv.Type().Key()), elem)
+			return nil
+		}
+	}
+	return nil
+}
+
+// parseINI 解析INI内容
+func parseINI(r io.Reader) ([]configEntry, error) {
+	var entries []configEntry
+	var section []string
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\uFEFF")
+		}
+		if text == "" || text[0] == ';' || text[0] == '#' {
+			continue
+		}
+		if text[0] == '[' {
+			if !strings.HasSuffix(text, "]") {
+				return nil, &LineError{Line: line, Err: errors.New("unterminated section header")}
+			}
+			name := strings.TrimSpace(text[1 : len(text)-1])
+			if name == "" {
+				return nil, &LineError{Line: line, Err: errors.New("empty section name")}
+			}
+			section = splitConfigKey(name)
+			continue
+		}
+		i := strings.IndexAny(text, "=:")
+		if i <= 0 {
+			return nil, &LineError{Line: line, Err: fmt.Errorf("expected key=value, got %q", text)}
+		}
+		key := strings.TrimSpace(text[:i])
+		value := strings.TrimSpace(text[i+1:])
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			value = value[1 : len(value)-1]
+		}
+		path := append(append([]string(nil), section...), splitConfigKey(key)...)
+		entries = append(entries, configEntry{path: path, value: value, line: line})
+	}
+	return entries, scanner.Err()
+}
+
+// parseProperties 解析.properties内容
+func parseProperties(r io.Reader) ([]configEntry, error) {
+	var entries []configEntry
+	scanner := bufio.NewScanner(r)
+	var logical strings.Builder
+	start := 0
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimLeft(scanner.Text(), " \t\f")
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\uFEFF")
+		}
+		if logical.Len() == 0 {
+			if text == "" || text[0] == '#' || text[0] == '!' {
+				continue
+			}
+			start = line
+		}
+		// 行尾奇数个反斜杠表示续行
+		if n := len(text) - len(strings.TrimRight(text, `\`)); n%2 == 1 {
+			logical.WriteString(text[:len(text)-1])
+			continue
+		}
+		logical.WriteString(text)
+		entry, err := parsePropertyLine(logical.String())
+		if err != nil {
+			return nil, &LineError{Line: start, Err: err}
+		}
+		entry.line = start
+		entries = append(entries, entry)
+		logical.Reset()
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if logical.Len() > 0 {
+		entry, err := parsePropertyLine(logical.String())
+		if err != nil {
+			return nil, &LineError{Line: start, Err: err}
+		}
+		entry.line = start
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// parsePropertyLine 解析一个逻辑行，键与值之间以=、:或空白分隔
+func parsePropertyLine(s string) (configEntry, error) {
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		if c == '\\' {
+			i += 2
+			continue
+		}
+		if c == '=' || c == ':' || c == ' ' || c == '\t' || c == '\f' {
+			break
+		}
+		i++
+	}
+	key, err := unescapeProperty(s[:min(i, len(s))])
+	if err != nil {
+		return configEntry{}, err
+	}
+	rest := strings.TrimLeft(s[min(i, len(s)):], " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+	value, err := unescapeProperty(rest)
+	if err != nil {
+		return configEntry{}, fmt.Errorf("%s: %w", key, err)
+	}
+	return configEntry{path: splitConfigKey(key), value: value}, nil
+}
+
+// unescapeProperty 处理.properties中的转义字符
+func unescapeProperty(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		if i >= len(s) {
+			break
+		}
+		switch s[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u':
+			if i+5 > len(s) {
+				return "", errors.New(`malformed \uXXXX escape`)
+			}
+			r, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", errors.New(`malformed \uXXXX escape`)
+			}
+			b.WriteRune(rune(r))
+			i += 4
+		default:
+			// 其余字符（包括=、:、空白、\）原样保留
+			r, size := utf8.DecodeRuneInString(s[i:])
+			b.WriteRune(r)
+			i += size - 1
+		}
+	}
+	return b.String(), nil
+}
+
+// splitConfigKey 按"."拆分键名并去除各段两侧的空白
+func splitConfigKey(key string) []string {
+	parts := strings.Split(key, ".")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
@@ -0,0 +1,128 @@
+/*
+--------------------------------
+@Create 2026/10/18 10:40
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 10:40
+@Description 整数解析辅助函数
+--------------------------------
+本文件实现了整数类字符串值的解析逻辑，支持可选的进制前缀（0x/0o/0b）、
+数字分隔符（1_000_000）以及传统的前导零八进制写法，JSON、YAML与数据库路径共用。
+*/
+
+package strval
+
+import (
+	"strconv"
+	"strings"
+)
+
+// IntSyntax 定义整数字符串允许的扩展语法，可按位组合
+type IntSyntax uint
+
+const (
+	// IntBasePrefix 允许0x/0X（十六进制）、0o/0O（八进制）、0b/0B（二进制）进制前缀
+	IntBasePrefix IntSyntax = 1 << iota
+	// IntUnderscore 允许数字之间使用下划线分隔，如"1_000_000"
+	IntUnderscore
+	// IntLegacyOctal 将前导零的数字按八进制解析，如"010"解析为8
+	//
+	// 该写法存在歧义（"010"也可能表示十进制的10），因此与IntBasePrefix分开控制
+	IntLegacyOctal
+)
+
+// IntSyntaxAll 启用所有整数扩展语法
+const IntSyntaxAll = IntBasePrefix | IntUnderscore | IntLegacyOctal
+
+// parseInt 解析字符串形式的整数
+// 参数:
+//   - input: 输入字符串
+//   - bitSize: 结果的位数（0表示int的位数）
+//   - o: 解析选项
+//
+// 返回值:
+//   - int64: 解析后的整数
+//   - error: 解析过程中的错误，格式与strconv.ParseInt一致
+//
+// 说明：始终允许前后空白与前导"+"号，其余扩展语法由Options.IntSyntax控制；
+// 包含千位分组符或小数点的输入按Options.NumberFormats解析；全角字符与中文数字由Options.Unicode控制
+// input可能引用调用方的缓冲区（见bytesView），错误中保存的是它的副本
+func parseInt(input string, bitSize int, o *Options) (int64, error) {
+	s, chinese, err := normalizeNumberUnicode(input, o)
+	if err == nil && chinese != nil {
+		var v int64
+		if v, err = ratToInt(chinese, bitSize); err == nil {
+			return v, nil
+		}
+	}
+	if err != nil {
+		return 0, &strconv.NumError{Func: "ParseInt", Num: strings.Clone(input), Err: err}
+	}
+
+	localized, ok, err := parseLocalized(s, o, func(c string) (int64, error) {
+		return strconv.ParseInt(c, 10, bitSize)
+	})
+	if ok {
+		if err != nil {
+			return 0, &strconv.NumError{Func: "ParseInt", Num: strings.Clone(input), Err: err}
+		}
+		return localized, nil
+	}
+
+	digits, base, ok := splitIntLiteral(strings.TrimSpace(s), o.IntSyntax)
+	if !ok {
+		return 0, &strconv.NumError{Func: "ParseInt", Num: strings.Clone(input), Err: strconv.ErrSyntax}
+	}
+	v, err := strconv.ParseInt(digits, base, bitSize)
+	if err != nil {
+		// 保留原始输入，便于定位问题
+		if numErr, ok := err.(*strconv.NumError); ok {
+			numErr.Num = strings.Clone(input)
+		}
+		return 0, err
+	}
+	return v, nil
+}
+
+// splitIntLiteral 拆分整数字面量，返回去除前缀与分隔符后的带符号数字串及其进制
+func splitIntLiteral(s string, syntax IntSyntax) (string, int, bool) {
+	sign := ""
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		if s[0] == '-' {
+			sign = "-"
+		}
+		s = s[1:]
+	}
+	if s == "" {
+		return "", 0, false
+	}
+
+	base := 10
+	if syntax&IntBasePrefix != 0 && len(s) > 2 && s[0] == '0' {
+		switch s[1] {
+		case 'x', 'X':
+			base, s = 16, s[2:]
+		case 'o', 'O':
+			base, s = 8, s[2:]
+		case 'b', 'B':
+			base, s = 2, s[2:]
+		}
+	}
+	if base == 10 && syntax&IntLegacyOctal != 0 && len(s) > 1 && s[0] == '0' {
+		base, s = 8, s[1:]
+	}
+
+	if syntax&IntUnderscore != 0 && strings.Contains(s, "_") {
+		// 下划线只能出现在数字之间
+		if s[0] == '_' || s[len(s)-1] == '_' || strings.Contains(s, "__") {
+			return "", 0, false
+		}
+		s = strings.ReplaceAll(s, "_", "")
+	}
+
+	// 符号只允许出现一次，由strconv负责校验其余字符
+	if s == "" || s[0] == '+' || s[0] == '-' {
+		return "", 0, false
+	}
+	return sign + s, base, true
+}
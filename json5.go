@@ -0,0 +1,574 @@
+/*
+--------------------------------
+@Create 2026/10/18 23:55
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 23:55
+@Description 手工编辑配置文件使用的JSON5子集
+--------------------------------
+手工编辑的JSON配置中常见的注释、末尾逗号与不带引号的键会使json.Unmarshal在strval的宽松解析生效前就失败。
+本文件提供JSON5子集到标准JSON的转换，转换后的数据交给encoding/json与strval类型的UnmarshalJSON处理：
+1. //行注释与以/*开始的块注释
+2. 对象与数组中的末尾逗号
+3. 单引号字符串，以及\x41、\v、\0与反斜杠续行等转义
+4. 不带引号的键，如{name: "app"}
+5. 十六进制数字0x1F，以及+1、.5、5.等形式的数字
+6. Infinity、-Infinity与NaN，转换为字符串"Infinity"、"-Infinity"与"NaN"，由Float按NonFiniteInput选项解析
+
+语法错误与encoding/json报告的类型错误都以JSON5Error返回，其中的行号与列号对应原始输入。
+*/
+
+package strval
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// JSON5Error JSON5数据中某一位置的错误，行号与列号对应原始输入
+type JSON5Error struct {
+	// Line 行号，从1开始
+	Line int
+	// Column 列号，从1开始，按字符计算
+	Column int
+	// Err 具体的错误原因，类型错误时为*json.UnmarshalTypeError
+	Err error
+}
+
+// Error 实现error接口
+func (e *JSON5Error) Error() string {
+	return fmt.Sprintf("strval: json5 line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+// Unwrap 返回具体的错误原因
+func (e *JSON5Error) Unwrap() error {
+	return e.Err
+}
+
+// json5Mark 记录转换结果中某个值的起始位置对应的原始位置
+type json5Mark struct {
+	out, src int
+}
+
+// json5Normalizer 将JSON5子集转换为标准JSON
+type json5Normalizer struct {
+	src   []byte
+	pos   int
+	out   []byte
+	marks []json5Mark
+}
+
+// json5MaxDepth 嵌套的最大深度，与encoding/json保持一致
+const json5MaxDepth = 10000
+
+// NormalizeJSON5 将JSON5子集转换为标准JSON
+// 参数:
+//   - data: JSON5数据
+//
+// 返回值:
+//   - []byte: 标准JSON数据，Infinity与NaN转换为字符串
+//   - error: 语法错误时返回*JSON5Error
+func NormalizeJSON5(data []byte) ([]byte, error) {
+	n := &json5Normalizer{src: data}
+	if err := n.normalize(); err != nil {
+		return nil, err
+	}
+	return n.out, nil
+}
+
+// DecodeJSON5 将JSON5数据转换为标准JSON后反序列化到v
+// 参数:
+//   - data: JSON5数据
+//   - v: 目标值指针
+//   - opts: 单次调用选项，为空时与json.Unmarshal一致使用各类型的UnmarshalJSON，否则与DecodeJSON一致
+//
+// 返回值:
+//   - error: 语法错误以及encoding/json报告的类型错误以包含原始行号与列号的*JSON5Error返回；
+//     strval字段的解析失败与UnmarshalJSON一致只记录日志
+func DecodeJSON5(data []byte, v any, opts ...Option) error {
+	n := &json5Normalizer{src: data}
+	if err := n.normalize(); err != nil {
+		return err
+	}
+	var err error
+	if len(opts) == 0 {
+		err = json.Unmarshal(n.out, v)
+	} else {
+		err = DecodeJSON(n.out, v, opts...)
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return n.errorAt(n.srcOffset(int(typeErr.Offset)), err)
+	}
+	return err
+}
+
+// normalize 转换整个输入，顶层只能有一个值
+func (n *json5Normalizer) normalize() error {
+	n.out = make([]byte, 0, len(n.src))
+	if err := n.skip(); err != nil {
+		return err
+	}
+	if n.pos >= len(n.src) {
+		return n.errorAt(n.pos, errors.New("unexpected end of input"))
+	}
+	if err := n.value(0); err != nil {
+		return err
+	}
+	if err := n.skip(); err != nil {
+		return err
+	}
+	if n.pos < len(n.src) {
+		return n.errorAt(n.pos, fmt.Errorf("unexpected %q after top-level value", n.peekRune()))
+	}
+	return nil
+}
+
+// value 转换一个值
+func (n *json5Normalizer) value(depth int) error {
+	if depth > json5MaxDepth {
+		return n.errorAt(n.pos, errors.New("exceeded max depth"))
+	}
+	if n.pos >= len(n.src) {
+		return n.errorAt(n.pos, errors.New("unexpected end of input"))
+	}
+	n.marks = append(n.marks, json5Mark{out: len(n.out), src: n.pos})
+	switch c := n.src[n.pos]; {
+	case c == '{':
+		return n.object(depth)
+	case c == '[':
+		return n.array(depth)
+	case c == '"' || c == '\'':
+		return n.string()
+	case c == '-' || c == '+' || c == '.' || c >= '0' && c <= '9':
+		return n.number()
+	}
+	start := n.pos
+	switch ident := n.identifier(); ident {
+	case "true", "false", "null":
+		n.out = append(n.out, ident...)
+	case "Infinity", "NaN":
+		n.out = strconv.AppendQuote(n.out, ident)
+	default:
+		n.pos = start
+		return n.errorAt(start, fmt.Errorf("unexpected %q", n.peekRune()))
+	}
+	return nil
+}
+
+// object 转换一个对象，键可以不带引号，允许末尾逗号
+func (n *json5Normalizer) object(depth int) error {
+	n.pos++
+	n.out = append(n.out, '{')
+	for first := true; ; first = false {
+		if err := n.skip(); err != nil {
+			return err
+		}
+		if n.pos >= len(n.src) {
+			return n.errorAt(n.pos, errors.New("unterminated object"))
+		}
+		if n.src[n.pos] == '}' {
+			n.pos++
+			n.out = append(n.out, '}')
+			return nil
+		}
+		if !first {
+			n.out = append(n.out, ',')
+		}
+		if err := n.key(); err != nil {
+			return err
+		}
+		if err := n.skip(); err != nil {
+			return err
+		}
+		if n.pos >= len(n.src) || n.src[n.pos] != ':' {
+			return n.errorAt(n.pos, errors.New("expected ':' after object key"))
+		}
+		n.pos++
+		n.out = append(n.out, ':')
+		if err := n.skip(); err != nil {
+			return err
+		}
+		if err := n.value(depth + 1); err != nil {
+			return err
+		}
+		if err := n.separator('}'); err != nil {
+			return err
+		}
+	}
+}
+
+// array 转换一个数组，允许末尾逗号
+func (n *json5Normalizer) array(depth int) error {
+	n.pos++
+	n.out = append(n.out, '[')
+	for first := true; ; first = false {
+		if err := n.skip(); err != nil {
+			return err
+		}
+		if n.pos >= len(n.src) {
+			return n.errorAt(n.pos, errors.New("unterminated array"))
+		}
+		if n.src[n.pos] == ']' {
+			n.pos++
+			n.out = append(n.out, ']')
+			return nil
+		}
+		if !first {
+			n.out = append(n.out, ',')
+		}
+		if err := n.value(depth + 1); err != nil {
+			return err
+		}
+		if err := n.separator(']'); err != nil {
+			return err
+		}
+	}
+}
+
+// separator 读取成员之后的逗号，紧跟结束符时不消耗结束符
+func (n *json5Normalizer) separator(end byte) error {
+	if err := n.skip(); err != nil {
+		return err
+	}
+	if n.pos < len(n.src) {
+		switch n.src[n.pos] {
+		case ',':
+			n.pos++
+			return nil
+		case end:
+			return nil
+		}
+	}
+	if n.pos >= len(n.src) {
+		return n.errorAt(n.pos, errors.New("unexpected end of input"))
+	}
+	return n.errorAt(n.pos, fmt.Errorf("expected ',' or '%c', got %q", end, n.peekRune()))
+}
+
+// key 转换对象的键，不带引号的键必须是标识符
+func (n *json5Normalizer) key() error {
+	n.marks = append(n.marks, json5Mark{out: len(n.out), src: n.pos})
+	if c := n.src[n.pos]; c == '"' || c == '\'' {
+		return n.string()
+	}
+	start := n.pos
+	ident := n.identifier()
+	if ident == "" {
+		return n.errorAt(start, fmt.Errorf("invalid object key starting with %q", n.peekRune()))
+	}
+	n.out = appendJSONString(n.out, ident)
+	return nil
+}
+
+// identifier 读取一个标识符，由字母、数字、_与$组成且不以数字开头
+func (n *json5Normalizer) identifier() string {
+	start := n.pos
+	for n.pos < len(n.src) {
+		r, size := utf8.DecodeRune(n.src[n.pos:])
+		if !(r == '_' || r == '$' || unicode.IsLetter(r) || n.pos > start && (unicode.IsDigit(r) || unicode.Is(unicode.Mn, r))) {
+			break
+		}
+		n.pos += size
+	}
+	return string(n.src[start:n.pos])
+}
+
+// string 转换单引号或双引号字符串
+func (n *json5Normalizer) string() error {
+	start := n.pos
+	quote := n.src[n.pos]
+	n.pos++
+	var s []byte
+	for {
+		if n.pos >= len(n.src) {
+			return n.errorAt(start, errors.New("unterminated string"))
+		}
+		c := n.src[n.pos]
+		switch {
+		case c == quote:
+			n.pos++
+			n.out = appendJSONString(n.out, string(s))
+			return nil
+		case c == '\n' || c == '\r':
+			return n.errorAt(n.pos, errors.New("unescaped line break in string"))
+		case c != '\\':
+			s = append(s, c)
+			n.pos++
+			continue
+		}
+
+		escape := n.pos
+		n.pos++
+		if n.pos >= len(n.src) {
+			return n.errorAt(start, errors.New("unterminated string"))
+		}
+		c = n.src[n.pos]
+		n.pos++
+		switch c {
+		case 'b':
+			s = append(s, '\b')
+		case 'f':
+			s = append(s, '\f')
+		case 'n':
+			s = append(s, '\n')
+		case 'r':
+			s = append(s, '\r')
+		case 't':
+			s = append(s, '\t')
+		case 'v':
+			s = append(s, '\v')
+		case '0':
+			if n.pos < len(n.src) && n.src[n.pos] >= '0' && n.src[n.pos] <= '9' {
+				return n.errorAt(escape, errors.New("octal escape is not allowed"))
+			}
+			s = append(s, 0)
+		case 'x', 'u':
+			digits := 2
+			if c == 'u' {
+				digits = 4
+			}
+			if n.pos+digits > len(n.src) {
+				return n.errorAt(escape, fmt.Errorf(`malformed \%c escape`, c))
+			}
+			code, err := strconv.ParseUint(string(n.src[n.pos:n.pos+digits]), 16, 32)
+			if err != nil {
+				return n.errorAt(escape, fmt.Errorf(`malformed \%c escape`, c))
+			}
+			n.pos += digits
+			r := rune(code)
+			if utf16.IsSurrogate(r) && n.pos+6 <= len(n.src) && n.src[n.pos] == '\\' && n.src[n.pos+1] == 'u' {
+				if low, err := strconv.ParseUint(string(n.src[n.pos+2:n.pos+6]), 16, 32); err == nil {
+					if combined := utf16.DecodeRune(r, rune(low)); combined != utf8.RuneError {
+						r = combined
+						n.pos += 6
+					}
+				}
+			}
+			s = utf8.AppendRune(s, r)
+		case '\n':
+			// 反斜杠续行
+		case '\r':
+			// 反斜杠续行，\r\n视为一个换行
+			if n.pos < len(n.src) && n.src[n.pos] == '\n' {
+				n.pos++
+			}
+		default:
+			if c >= '1' && c <= '9' {
+				return n.errorAt(escape, errors.New("octal escape is not allowed"))
+			}
+			// 其余字符（包括'、"、\、/）原样保留
+			n.pos--
+			r, size := utf8.DecodeRune(n.src[n.pos:])
+			if r == '\u2028' || r == '\u2029' {
+				// 反斜杠续行
+				n.pos += size
+				continue
+			}
+			s = append(s, n.src[n.pos:n.pos+size]...)
+			n.pos += size
+		}
+	}
+}
+
+// number 转换数字，支持十六进制、前导+号、前导或末尾的小数点以及±Infinity、NaN
+func (n *json5Normalizer) number() error {
+	start := n.pos
+	neg := false
+	if c := n.src[n.pos]; c == '+' || c == '-' {
+		neg = c == '-'
+		n.pos++
+	}
+	if n.pos < len(n.src) && (n.src[n.pos] == 'I' || n.src[n.pos] == 'N') {
+		switch ident := n.identifier(); ident {
+		case "Infinity":
+			if neg {
+				ident = "-" + ident
+			}
+			n.out = strconv.AppendQuote(n.out, ident)
+			return nil
+		case "NaN":
+			n.out = strconv.AppendQuote(n.out, ident)
+			return nil
+		}
+		return n.errorAt(start, errors.New("invalid number"))
+	}
+
+	if n.pos+1 < len(n.src) && n.src[n.pos] == '0' && (n.src[n.pos+1] == 'x' || n.src[n.pos+1] == 'X') {
+		n.pos += 2
+		digits := n.pos
+		for n.pos < len(n.src) && isHexDigit(n.src[n.pos]) {
+			n.pos++
+		}
+		value, err := strconv.ParseUint(string(n.src[digits:n.pos]), 16, 64)
+		if err != nil {
+			return n.errorAt(start, fmt.Errorf("invalid hexadecimal number %q", n.src[start:n.pos]))
+		}
+		if neg && value != 0 {
+			n.out = append(n.out, '-')
+		}
+		n.out = strconv.AppendUint(n.out, value, 10)
+		return n.endOfNumber(start)
+	}
+
+	if neg {
+		n.out = append(n.out, '-')
+	}
+	intStart := n.pos
+	for n.pos < len(n.src) && isDigit(n.src[n.pos]) {
+		n.pos++
+	}
+	intPart := n.src[intStart:n.pos]
+	if len(intPart) > 1 && intPart[0] == '0' {
+		return n.errorAt(start, errors.New("leading zero in number"))
+	}
+	var fracPart []byte
+	hasDot := n.pos < len(n.src) && n.src[n.pos] == '.'
+	if hasDot {
+		n.pos++
+		fracStart := n.pos
+		for n.pos < len(n.src) && isDigit(n.src[n.pos]) {
+			n.pos++
+		}
+		fracPart = n.src[fracStart:n.pos]
+	}
+	if len(intPart) == 0 && len(fracPart) == 0 {
+		return n.errorAt(start, errors.New("invalid number"))
+	}
+	if len(intPart) == 0 {
+		n.out = append(n.out, '0')
+	}
+	n.out = append(n.out, intPart...)
+	if len(fracPart) > 0 {
+		n.out = append(n.out, '.')
+		n.out = append(n.out, fracPart...)
+	}
+	if n.pos < len(n.src) && (n.src[n.pos] == 'e' || n.src[n.pos] == 'E') {
+		n.pos++
+		n.out = append(n.out, 'e')
+		if n.pos < len(n.src) && (n.src[n.pos] == '+' || n.src[n.pos] == '-') {
+			n.out = append(n.out, n.src[n.pos])
+			n.pos++
+		}
+		expStart := n.pos
+		for n.pos < len(n.src) && isDigit(n.src[n.pos]) {
+			n.pos++
+		}
+		if n.pos == expStart {
+			return n.errorAt(start, errors.New("missing exponent digits"))
+		}
+		n.out = append(n.out, n.src[expStart:n.pos]...)
+	}
+	return n.endOfNumber(start)
+}
+
+// endOfNumber 检查数字后没有紧跟其他标识符字符，如0x1G、12abc
+func (n *json5Normalizer) endOfNumber(start int) error {
+	if n.pos < len(n.src) {
+		if r, _ := utf8.DecodeRune(n.src[n.pos:]); r == '_' || r == '$' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return n.errorAt(start, errors.New("invalid number"))
+		}
+	}
+	return nil
+}
+
+// skip 跳过空白与注释
+func (n *json5Normalizer) skip() error {
+	for n.pos < len(n.src) {
+		c := n.src[n.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f':
+			n.pos++
+		case c == '/' && n.pos+1 < len(n.src) && n.src[n.pos+1] == '/':
+			end := bytes.IndexAny(n.src[n.pos:], "\r\n")
+			if end < 0 {
+				n.pos = len(n.src)
+			} else {
+				n.pos += end
+			}
+		case c == '/' && n.pos+1 < len(n.src) && n.src[n.pos+1] == '*':
+			end := bytes.Index(n.src[n.pos+2:], []byte("*/"))
+			if end < 0 {
+				return n.errorAt(n.pos, errors.New("unterminated block comment"))
+			}
+			n.pos += end + 4
+		case c >= utf8.RuneSelf:
+			r, size := utf8.DecodeRune(n.src[n.pos:])
+			if r != '\uFEFF' && r != '\u2028' && r != '\u2029' && !unicode.Is(unicode.Zs, r) {
+				return nil
+			}
+			n.pos += size
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// peekRune 返回当前位置的字符
+func (n *json5Normalizer) peekRune() rune {
+	r, _ := utf8.DecodeRune(n.src[n.pos:])
+	return r
+}
+
+// srcOffset 将转换结果中的偏移映射为原始输入中最近的值的起始偏移
+func (n *json5Normalizer) srcOffset(out int) int {
+	i := sort.Search(len(n.marks), func(i int) bool { return n.marks[i].out >= out })
+	if i == 0 {
+		return 0
+	}
+	return n.marks[i-1].src
+}
+
+// errorAt 生成原始输入中某一偏移处的错误
+func (n *json5Normalizer) errorAt(offset int, err error) *JSON5Error {
+	offset = min(offset, len(n.src))
+	line, lineStart := 1, 0
+	for i, c := range n.src[:offset] {
+		if c == '\n' {
+			line, lineStart = line+1, i+1
+		}
+	}
+	return &JSON5Error{Line: line, Column: utf8.RuneCount(n.src[lineStart:offset]) + 1, Err: err}
+}
+
+// appendJSONString 将字符串以JSON双引号字符串的形式追加到dst，不转义HTML字符
+func appendJSONString(dst []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	dst = append(dst, '"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			dst = append(dst, '\\', c)
+		case c == '\n':
+			dst = append(dst, '\\', 'n')
+		case c == '\r':
+			dst = append(dst, '\\', 'r')
+		case c == '\t':
+			dst = append(dst, '\\', 't')
+		case c < 0x20:
+			dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+		default:
+			dst = append(dst, c)
+		}
+	}
+	return append(dst, '"')
+}
+
+// isDigit 判断是否为十进制数字
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isHexDigit 判断是否为十六进制数字
+func isHexDigit(c byte) bool {
+	return isDigit(c) || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
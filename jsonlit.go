@@ -0,0 +1,173 @@
+/*
+--------------------------------
+@Create 2026/10/19 01:10
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 01:10
+@Description JSON标量字面量的直接解析
+--------------------------------
+各类型的UnmarshalJSON原先依次尝试json.Unmarshal到int、string等类型，每次失败都会分配一个错误，
+在高频解码的场景中开销明显。本文件提供按首字节识别JSON标量字面量的辅助函数：
+1. jsonLiteral 去除空白后按首字节判断null、true、false、数字与字符串，数字按JSON语法严格校验
+2. jsonString 返回字符串字面量的内容，不含转义时直接引用输入数据而不复制
+3. jsonScalar 将字面量识别为标量，交给各类型共用的coerce方法转换
+
+解析成功的常见路径不使用反射，也不分配内存；只有包含转义的字符串才交给encoding/json解码。
+*/
+
+package strval
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// JSON字面量的类型，与jsontext.Kind的取值保持一致
+const (
+	jsonInvalid byte = 0
+	jsonNull    byte = 'n'
+	jsonTrue    byte = 't'
+	jsonFalse   byte = 'f'
+	jsonNumber  byte = '0'
+	jsonStr     byte = '"'
+)
+
+// jsonLiteral 去除JSON空白后按首字节识别标量字面量
+// 参数:
+//   - data: 一个完整的JSON值
+//
+// 返回值:
+//   - byte: 字面量的类型，数组、对象与无效输入为jsonInvalid
+//   - []byte: 去除空白后的字面量
+func jsonLiteral(data []byte) (byte, []byte) {
+	lit := trimJSONSpace(data)
+	if len(lit) == 0 {
+		return jsonInvalid, lit
+	}
+	switch c := lit[0]; {
+	case c == 'n' && string(lit) == "null":
+		return jsonNull, lit
+	case c == 't' && string(lit) == "true":
+		return jsonTrue, lit
+	case c == 'f' && string(lit) == "false":
+		return jsonFalse, lit
+	case c == '"' && len(lit) >= 2 && lit[len(lit)-1] == '"':
+		return jsonStr, lit
+	case (c == '-' || c >= '0' && c <= '9') && isJSONNumber(lit):
+		return jsonNumber, lit
+	}
+	return jsonInvalid, lit
+}
+
+// jsonScalar 将一个完整的JSON值识别为标量，供各类型的unmarshalJSON使用
+//
+// 说明：返回的标量可能引用data的内存，只能在本次解析中使用
+func jsonScalar(data []byte) scalar {
+	kind, lit := jsonLiteral(data)
+	switch kind {
+	case jsonNull:
+		return scalar{kind: scalarNull}
+	case jsonTrue, jsonFalse:
+		return scalar{kind: scalarBool, b: kind == jsonTrue, text: bytesView(lit), hasText: true, borrowed: true}
+	case jsonNumber:
+		return numberScalar(bytesView(lit), true)
+	case jsonStr:
+		if s, ok := jsonString(lit); ok {
+			return textScalar(s, true)
+		}
+	}
+	return invalidScalar(fmt.Errorf("unsupported JSON value %s", lit))
+}
+
+// jsonString 返回JSON字符串字面量的内容
+// 参数:
+//   - lit: jsonLiteral识别为jsonStr的字面量
+//
+// 返回值:
+//   - string: 字符串内容
+//   - bool: 字面量是否有效
+//
+// 说明：不含转义时直接引用lit的内存，调用方只能在本次解析中使用，不得保留；
+// 需要保留字符串（如String类型）时应使用strings.Clone复制
+func jsonString(lit []byte) (string, bool) {
+	body := lit[1 : len(lit)-1]
+	simple := utf8.Valid(body)
+	for i := 0; simple && i < len(body); i++ {
+		if c := body[i]; c == '\\' || c == '"' || c < 0x20 {
+			simple = false
+		}
+	}
+	if simple {
+		return bytesView(body), true
+	}
+	var s string
+	if err := json.Unmarshal(lit, &s); err != nil {
+		return "", false
+	}
+	return s, true
+}
+
+// bytesView 返回引用b的内存的字符串，不复制，调用方不得保留
+func bytesView(b []byte) string {
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
+
+// trimJSONSpace 去除JSON空白
+func trimJSONSpace(data []byte) []byte {
+	for len(data) > 0 && isJSONSpace(data[0]) {
+		data = data[1:]
+	}
+	for len(data) > 0 && isJSONSpace(data[len(data)-1]) {
+		data = data[:len(data)-1]
+	}
+	return data
+}
+
+// isJSONSpace 判断是否为JSON空白
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// isJSONNumber 按JSON语法校验数字：-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?
+func isJSONNumber(b []byte) bool {
+	i := 0
+	if i < len(b) && b[i] == '-' {
+		i++
+	}
+	switch {
+	case i < len(b) && b[i] == '0':
+		i++
+	case i < len(b) && b[i] >= '1' && b[i] <= '9':
+		for i < len(b) && isDigit(b[i]) {
+			i++
+		}
+	default:
+		return false
+	}
+	if i < len(b) && b[i] == '.' {
+		i++
+		start := i
+		for i < len(b) && isDigit(b[i]) {
+			i++
+		}
+		if i == start {
+			return false
+		}
+	}
+	if i < len(b) && (b[i] == 'e' || b[i] == 'E') {
+		i++
+		if i < len(b) && (b[i] == '+' || b[i] == '-') {
+			i++
+		}
+		start := i
+		for i < len(b) && isDigit(b[i]) {
+			i++
+		}
+		if i == start {
+			return false
+		}
+	}
+	return i == len(b)
+}
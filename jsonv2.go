@@ -0,0 +1,123 @@
+//go:build goexperiment.jsonv2 && go1.27
+
+/*
+--------------------------------
+@Create 2026/10/19 00:30
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 00:30
+@Description encoding/json/v2的流式编解码接口
+--------------------------------
+本文件在goexperiment.jsonv2构建标签下为各类型实现json.MarshalerTo与json.UnmarshalerFrom：
+反序列化时从jsontext.Decoder读取一个值，不复制地交给与UnmarshalJSON相同的字面量解析逻辑，
+按首字节直接分派数字、字符串、布尔值或null，解析规则、零值与错误日志与UnmarshalJSON保持一致。
+序列化时直接写出词法单元，需要按选项格式化的值仍使用与MarshalJSON相同的逻辑。
+*/
+
+package strval
+
+import (
+	"encoding/json/jsontext"
+	"strconv"
+)
+
+// readJSONValue 从解码器读取一个值并交给unmarshal解析
+//
+// 说明：读取到的值引用解码器的缓冲区，只在unmarshal执行期间有效
+func readJSONValue(dec *jsontext.Decoder, unmarshal func(data []byte, o *Options)) error {
+	raw, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	unmarshal(raw, currentOptions())
+	return nil
+}
+
+// writeJSONValue 按选项序列化后将JSON写入编码器，供需要按选项格式化的值使用
+func writeJSONValue(enc *jsontext.Encoder, m optionMarshaler, o *Options) error {
+	data, err := m.marshalJSON(o)
+	if err != nil {
+		return err
+	}
+	return enc.WriteValue(data)
+}
+
+// MarshalJSONTo 实现json.MarshalerTo接口，输出形式与MarshalJSON一致
+func (b Bool) MarshalJSONTo(enc *jsontext.Encoder) error {
+	o := currentOptions()
+	if o.BoolOutput == BoolOutputNative {
+		return enc.WriteToken(jsontext.Bool(bool(b)))
+	}
+	return writeJSONValue(enc, b, o)
+}
+
+// UnmarshalJSONFrom 实现json.UnmarshalerFrom接口，解析规则与UnmarshalJSON一致
+//
+// 说明：只返回语法错误与读取错误，值无效时与UnmarshalJSON一致返回false并记录错误日志
+func (b *Bool) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return readJSONValue(dec, b.unmarshalJSON)
+}
+
+// MarshalJSONTo 实现json.MarshalerTo接口，输出形式与MarshalJSON一致
+func (i Int) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if currentOptions().NumberOutput == NumberOutputString {
+		return enc.WriteToken(jsontext.String(strconv.Itoa(int(i))))
+	}
+	return enc.WriteToken(jsontext.Int(int64(i)))
+}
+
+// UnmarshalJSONFrom 实现json.UnmarshalerFrom接口，解析规则与UnmarshalJSON一致
+//
+// 说明：只返回语法错误与读取错误，值无效时与UnmarshalJSON一致返回0并记录错误日志
+func (i *Int) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return readJSONValue(dec, i.unmarshalJSON)
+}
+
+// MarshalJSONTo 实现json.MarshalerTo接口，输出形式与MarshalJSON一致
+func (f Float) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return writeJSONValue(enc, f, currentOptions())
+}
+
+// UnmarshalJSONFrom 实现json.UnmarshalerFrom接口，解析规则与UnmarshalJSON一致
+//
+// 说明：只返回语法错误与读取错误，值无效时与UnmarshalJSON一致返回0并记录错误日志
+func (f *Float) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return readJSONValue(dec, f.unmarshalJSON)
+}
+
+// MarshalJSONTo 实现json.MarshalerTo接口，总是输出JSON字符串
+func (s String) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return enc.WriteToken(jsontext.String(string(s)))
+}
+
+// UnmarshalJSONFrom 实现json.UnmarshalerFrom接口，解析规则与UnmarshalJSON一致
+//
+// 说明：数值与布尔值转换为字符串，数组与对象得到空字符串并记录错误日志
+func (s *String) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return readJSONValue(dec, s.unmarshalJSON)
+}
+
+// MarshalJSONTo 实现json.MarshalerTo接口，无效值输出null，有效值的输出形式与MarshalJSON一致
+func (n NullBool) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !n.Valid {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return Bool(n.Bool).MarshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom 实现json.UnmarshalerFrom接口，解析规则与UnmarshalJSON一致
+//
+// 说明：只返回语法错误与读取错误，值无效时与UnmarshalJSON一致得到无效值并记录错误日志
+func (n *NullBool) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return readJSONValue(dec, n.unmarshalJSON)
+}
+
+// MarshalJSONTo 实现json.MarshalerTo接口，值未修改时输出原始字面量
+func (p Preserved[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return writeJSONValue(enc, p, currentOptions())
+}
+
+// UnmarshalJSONFrom 实现json.UnmarshalerFrom接口，解析规则与T相同，同时记录原始字面量
+func (p *Preserved[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return readJSONValue(dec, p.unmarshalJSON)
+}
@@ -0,0 +1,342 @@
+/*
+--------------------------------
+@Create 2026/10/18 22:50
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 22:50
+@Description logfmt与libpq风格DSN的键值对编解码
+--------------------------------
+日志管道中的logfmt记录与数据库的DSN都由key=value对组成，所有值都是字符串。本文件提供两种语法的解析与输出：
+1. logfmt：`level=info msg="user login" retries=3 debug`，值可以用双引号包裹并使用\"、\n、\t等转义，
+   不带值的键视为true
+2. libpq风格DSN：`host=db port=5432 sslmode=disable password='it\'s'`，等号两侧可以有空白，
+   值可以用单引号或双引号包裹，使用反斜杠转义引号与\\；URI形式（postgres://...）不在此列
+
+解析得到的键值对按带kv标签的结构体字段赋值，键名不区分大小写，没有kv标签时使用字段名，
+值使用与UnmarshalJSON中字符串相同的宽松解析逻辑，所有无效的值汇总为一个错误返回。
+*/
+
+package strval
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// KVError 键值对错误，DecodeLogfmt与DecodeDSN返回的汇总错误由若干KVError组成，可使用errors.As获取
+type KVError struct {
+	// Key 键名
+	Key string
+	// Value 值
+	Value string
+	// Err 具体的错误原因
+	Err error
+}
+
+// Error 实现error接口
+func (e *KVError) Error() string {
+	return fmt.Sprintf("strval: key %s: %v", e.Key, e.Err)
+}
+
+// Unwrap 返回具体的错误原因
+func (e *KVError) Unwrap() error {
+	return e.Err
+}
+
+// kvPair 解析得到的一个键值对
+type kvPair struct {
+	key, value string
+}
+
+// DecodeLogfmt 解析一条logfmt记录并赋值给结构体
+// 参数:
+//   - data: 一条logfmt记录，如`level=info msg="user login" retries=3`
+//   - v: 结构体指针
+//   - opts: 值解析时使用的单次调用选项
+//
+// 返回值:
+//   - error: 语法错误（包含出错的位置）时立即返回；所有无效的值汇总后返回，每一项为*KVError
+//
+// 说明：结构体中没有对应字段的键被忽略，重复的键以最后一个为准，空值解析为零值
+func DecodeLogfmt(data []byte, v any, opts ...Option) error {
+	pairs, err := parseLogfmt(string(data))
+	if err != nil {
+		return err
+	}
+	return decodePairs(pairs, v, resolveOptions(opts))
+}
+
+// EncodeLogfmt 将结构体输出为一条logfmt记录
+// 参数:
+//   - v: 结构体或结构体指针
+//   - opts: 值输出时使用的单次调用选项，如WithBoolOutput(BoolOutputYesNo)
+//
+// 返回值:
+//   - []byte: logfmt记录，不含换行符
+//   - error: 字段类型不支持时的错误
+//
+// 说明：键按字段在结构体中的顺序输出，带omitempty选项的零值字段不输出；
+// 值为空或包含空白、引号、等号及控制字符时使用双引号包裹
+func EncodeLogfmt(v any, opts ...Option) ([]byte, error) {
+	var b strings.Builder
+	err := encodePairs(v, resolveOptions(opts), func(key, value string) {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(key)
+		b.WriteByte('=')
+		if needsLogfmtQuote(value) {
+			b.WriteString(strconv.Quote(value))
+		} else {
+			b.WriteString(value)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(b.String()), nil
+}
+
+// DecodeDSN 解析libpq风格的key=value连接串并赋值给结构体
+// 参数:
+//   - dsn: 连接串，如`host=db port=5432 sslmode=disable`
+//   - v: 结构体指针
+//   - opts: 值解析时使用的单次调用选项
+//
+// 返回值:
+//   - error: 语法错误（包含出错的位置）时立即返回；所有无效的值汇总后返回，每一项为*KVError
+func DecodeDSN(dsn string, v any, opts ...Option) error {
+	pairs, err := parseDSN(dsn)
+	if err != nil {
+		return err
+	}
+	return decodePairs(pairs, v, resolveOptions(opts))
+}
+
+// EncodeDSN 将结构体输出为libpq风格的key=value连接串
+// 参数:
+//   - v: 结构体或结构体指针
+//   - opts: 值输出时使用的单次调用选项
+//
+// 返回值:
+//   - string: 连接串
+//   - error: 字段类型不支持时的错误
+//
+// 说明：值为空或包含空白、引号、反斜杠时使用单引号包裹并转义
+func EncodeDSN(v any, opts ...Option) (string, error) {
+	var b strings.Builder
+	err := encodePairs(v, resolveOptions(opts), func(key, value string) {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(key)
+		b.WriteByte('=')
+		if value == "" || strings.ContainsAny(value, `'"\`) || strings.IndexFunc(value, unicode.IsSpace) >= 0 {
+			b.WriteByte('\'')
+			b.WriteString(strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value))
+			b.WriteByte('\'')
+		} else {
+			b.WriteString(value)
+		}
+	})
+	return b.String(), err
+}
+
+// decodePairs 将键值对赋值给结构体字段
+func decodePairs(pairs []kvPair, v any, o *Options) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("strval: key=value decoding requires a non-nil struct pointer, got %T", v)
+	}
+	sv := rv.Elem()
+	fields := cachedFields(sv.Type(), "kv")
+	var errs []error
+	for _, p := range pairs {
+		f := lookupField(fields, p.key, true)
+		if f == nil {
+			continue
+		}
+		fv := fieldByIndex(sv, f.index)
+		if p.value == "" {
+			fv.SetZero()
+			continue
+		}
+		fo, err := fieldOptions(o, f.tag)
+		if err == nil {
+			err = setFieldText(fv, p.value, fo)
+		}
+		if err != nil {
+			errs = append(errs, &KVError{Key: p.key, Value: p.value, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// encodePairs 按字段顺序格式化结构体的各字段并交给write输出
+func encodePairs(v any, o *Options, write func(key, value string)) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("strval: key=value encoding requires a struct, got %T", v)
+	}
+	for _, f := range orderedFields(rv.Type(), "kv") {
+		fv, ok := fieldValue(rv, f.index)
+		if !ok || (f.hasOpt("omitempty") && fv.IsZero()) {
+			continue
+		}
+		fo, err := fieldOptions(o, f.tag)
+		if err != nil {
+			return err
+		}
+		value, err := formatFieldString(fv, fo)
+		if err != nil {
+			return fmt.Errorf("strval: key %s: %w", f.name, err)
+		}
+		write(f.name, value)
+	}
+	return nil
+}
+
+// parseLogfmt 解析logfmt记录
+func parseLogfmt(s string) ([]kvPair, error) {
+	var pairs []kvPair
+	i := 0
+	for {
+		for i < len(s) && isKVSpace(s[i]) {
+			i++
+		}
+		if i >= len(s) {
+			return pairs, nil
+		}
+		start := i
+		for i < len(s) && !isKVSpace(s[i]) && s[i] != '=' && s[i] != '"' {
+			i++
+		}
+		if i == start {
+			return nil, fmt.Errorf("strval: logfmt: offset %d: expected key", i)
+		}
+		key := s[start:i]
+		if i >= len(s) || s[i] != '=' {
+			if i < len(s) && s[i] == '"' {
+				return nil, fmt.Errorf("strval: logfmt: offset %d: unexpected '\"' in key", i)
+			}
+			// 不带值的键
+			pairs = append(pairs, kvPair{key, "true"})
+			continue
+		}
+		i++
+
+		if i < len(s) && s[i] == '"' {
+			end := closingQuote(s[i:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("strval: logfmt: offset %d: unterminated quoted value for key %s", i, key)
+			}
+			value, err := strconv.Unquote(s[i : i+end+1])
+			if err != nil {
+				return nil, fmt.Errorf("strval: logfmt: offset %d: invalid quoted value for key %s: %w", i, key, err)
+			}
+			pairs = append(pairs, kvPair{key, value})
+			i += end + 1
+			if i < len(s) && !isKVSpace(s[i]) {
+				return nil, fmt.Errorf("strval: logfmt: offset %d: expected space after quoted value", i)
+			}
+			continue
+		}
+		start = i
+		for i < len(s) && !isKVSpace(s[i]) {
+			if s[i] == '"' || s[i] == '=' {
+				return nil, fmt.Errorf("strval: logfmt: offset %d: unexpected '%c' in unquoted value for key %s", i, s[i], key)
+			}
+			i++
+		}
+		pairs = append(pairs, kvPair{key, s[start:i]})
+	}
+}
+
+// needsLogfmtQuote 判断logfmt值是否需要使用双引号包裹
+func needsLogfmtQuote(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || r == utf8.RuneError || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDSN 解析libpq风格的连接串
+//
+// 说明：值可以用单引号或双引号包裹，如retries="3"得到3，引号内的另一种引号按普通字符处理
+func parseDSN(s string) ([]kvPair, error) {
+	var pairs []kvPair
+	i := 0
+	for {
+		for i < len(s) && isKVSpace(s[i]) {
+			i++
+		}
+		if i >= len(s) {
+			return pairs, nil
+		}
+		start := i
+		for i < len(s) && !isKVSpace(s[i]) && s[i] != '=' {
+			i++
+		}
+		key := s[start:i]
+		if key == "" {
+			return nil, fmt.Errorf("strval: dsn: offset %d: expected key", i)
+		}
+		for i < len(s) && isKVSpace(s[i]) {
+			i++
+		}
+		if i >= len(s) || s[i] != '=' {
+			return nil, fmt.Errorf("strval: dsn: offset %d: missing '=' after key %s", i, key)
+		}
+		i++
+		for i < len(s) && isKVSpace(s[i]) {
+			i++
+		}
+
+		var value strings.Builder
+		var quote byte
+		if i < len(s) && (s[i] == '\'' || s[i] == '"') {
+			quote = s[i]
+			i++
+		}
+		quoted := quote != 0
+		for ; i < len(s); i++ {
+			c := s[i]
+			if quoted && c == quote || !quoted && isKVSpace(c) {
+				break
+			}
+			if c == '\\' {
+				i++
+				if i >= len(s) {
+					return nil, fmt.Errorf("strval: dsn: offset %d: unterminated escape for key %s", i, key)
+				}
+				c = s[i]
+			}
+			value.WriteByte(c)
+		}
+		if quoted {
+			if i >= len(s) {
+				return nil, fmt.Errorf("strval: dsn: offset %d: unterminated quoted value for key %s", i, key)
+			}
+			i++
+		}
+		pairs = append(pairs, kvPair{key, value.String()})
+	}
+}
+
+// isKVSpace 判断是否为键值对之间的空白
+func isKVSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
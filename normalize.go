@@ -0,0 +1,241 @@
+/*
+--------------------------------
+@Create 2026/10/18 16:10
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 16:10
+@Description 字符串预处理器管道
+--------------------------------
+本文件实现了有序、可组合的字符串预处理器（Normalizer）。预处理器在字符串到达解析器之前执行，
+可用于去除空白、货币符号、单位，或将"N/A"等占位符映射为空值。预处理器可以全局注册，
+也可以只为某种strval类型注册；JSON、YAML与数据库路径中所有字符串输入都会经过同一条管道。
+每次改写都会记录到Options.Report（如已设置）中。
+*/
+
+package strval
+
+import (
+	"strings"
+	"sync"
+)
+
+// Kind 标识strval类型的种类，用于按类型注册预处理器
+type Kind int
+
+const (
+	// KindBool Bool类型
+	KindBool Kind = iota + 1
+	// KindNullBool NullBool类型
+	KindNullBool
+	// KindInt Int类型
+	KindInt
+	// KindFloat Float类型
+	KindFloat
+	// KindString String类型
+	KindString
+)
+
+// String 返回类型种类的名称
+func (k Kind) String() string {
+	switch k {
+	case KindBool:
+		return "Bool"
+	case KindNullBool:
+		return "NullBool"
+	case KindInt:
+		return "Int"
+	case KindFloat:
+		return "Float"
+	case KindString:
+		return "String"
+	default:
+		return "Unknown"
+	}
+}
+
+// Normalizer 字符串预处理器
+type Normalizer struct {
+	// Name 预处理器名称，用于报告
+	Name string
+	// Func 预处理函数，返回处理后的字符串；第二个返回值为false时表示该输入为空值
+	Func func(s string) (string, bool)
+}
+
+// NewNormalizer 创建预处理器
+// 参数:
+//   - name: 预处理器名称
+//   - fn: 预处理函数，返回处理后的字符串与是否为有效值
+func NewNormalizer(name string, fn func(s string) (string, bool)) Normalizer {
+	return Normalizer{Name: name, Func: fn}
+}
+
+// Chain 将多个预处理器组合为一个，按顺序执行，遇到空值时停止
+func Chain(name string, normalizers ...Normalizer) Normalizer {
+	return Normalizer{Name: name, Func: func(s string) (string, bool) {
+		for _, n := range normalizers {
+			var ok bool
+			if s, ok = n.Func(s); !ok {
+				return s, false
+			}
+		}
+		return s, true
+	}}
+}
+
+// TrimSpace 去除前后空白的预处理器
+func TrimSpace() Normalizer {
+	return NewNormalizer("TrimSpace", func(s string) (string, bool) {
+		return strings.TrimSpace(s), true
+	})
+}
+
+// StripCurrency 去除货币符号与货币代码的预处理器，如"$1,200"、"¥30"、"12.5 EUR"
+// 参数:
+//   - symbols: 需要去除的符号与代码，为空时使用常见的货币符号
+func StripCurrency(symbols ...string) Normalizer {
+	if len(symbols) == 0 {
+		symbols = []string{"$", "¥", "￥", "€", "£", "₩", "₹", "元", "USD", "CNY", "RMB", "EUR", "GBP", "JPY"}
+	}
+	return NewNormalizer("StripCurrency", func(s string) (string, bool) {
+		return stripAffixes(s, symbols), true
+	})
+}
+
+// StripUnits 去除数字前后单位的预处理器，如"30 sec"、"512MB"、"85%"
+// 参数:
+//   - units: 需要去除的单位，匹配时不区分大小写
+func StripUnits(units ...string) Normalizer {
+	return NewNormalizer("StripUnits", func(s string) (string, bool) {
+		return stripAffixes(s, units), true
+	})
+}
+
+// NullTokens 将占位符映射为空值的预处理器，如"N/A"、"null"、"-"
+// 参数:
+//   - tokens: 表示空值的占位符，匹配时不区分大小写并去除前后空白
+func NullTokens(tokens ...string) Normalizer {
+	return NewNormalizer("NullTokens", func(s string) (string, bool) {
+		return s, !containsFold(tokens, strings.TrimSpace(s))
+	})
+}
+
+// stripAffixes 去除字符串前后出现的任一前缀或后缀（不区分大小写），并去除剩余部分的前后空白
+func stripAffixes(s string, affixes []string) string {
+	t := strings.TrimSpace(s)
+	for _, a := range affixes {
+		if a == "" {
+			continue
+		}
+		if len(t) >= len(a) && strings.EqualFold(t[:len(a)], a) {
+			t = strings.TrimSpace(t[len(a):])
+		}
+		if len(t) >= len(a) && strings.EqualFold(t[len(t)-len(a):], a) {
+			t = strings.TrimSpace(t[:len(t)-len(a)])
+		}
+	}
+	if t == strings.TrimSpace(s) {
+		return s
+	}
+	return t
+}
+
+// RegisterNormalizer 向全局选项注册适用于所有数值与布尔类型的预处理器
+//
+// 说明：String类型不会执行全局预处理器，需要时使用RegisterTypeNormalizer(KindString, ...)注册
+func RegisterNormalizer(normalizers ...Normalizer) {
+	updateDefaultOptions(func(o *Options) {
+		o.Normalizers = append(clipNormalizers(o.Normalizers), normalizers...)
+	})
+}
+
+// RegisterTypeNormalizer 向全局选项注册只适用于指定类型的预处理器，在全局预处理器之后执行
+func RegisterTypeNormalizer(kind Kind, normalizers ...Normalizer) {
+	updateDefaultOptions(func(o *Options) {
+		m := make(map[Kind][]Normalizer, len(o.TypeNormalizers)+1)
+		for k, v := range o.TypeNormalizers {
+			m[k] = v
+		}
+		m[kind] = append(clipNormalizers(m[kind]), normalizers...)
+		o.TypeNormalizers = m
+	})
+}
+
+// clipNormalizers 返回容量等于长度的切片，追加时不会修改已发布的选项
+func clipNormalizers(n []Normalizer) []Normalizer {
+	return n[:len(n):len(n)]
+}
+
+// normalize 依次执行适用于指定类型的预处理器
+// 参数:
+//   - kind: strval类型种类
+//   - s: 输入字符串
+//
+// 返回值:
+//   - string: 处理后的字符串
+//   - bool: 是否为有效值，预处理器将输入映射为空值时为false
+func (o *Options) normalize(kind Kind, s string) (string, bool) {
+	var typed []Normalizer
+	if o.TypeNormalizers != nil {
+		typed = o.TypeNormalizers[kind]
+	}
+	if len(typed) == 0 && (len(o.Normalizers) == 0 || kind == KindString) {
+		return s, true
+	}
+
+	run := func(list []Normalizer) bool {
+		for _, n := range list {
+			out, ok := n.Func(s)
+			if out != s || !ok {
+				o.Report.add(ReportEntry{Path: o.path, Type: kind.String(), Input: s, Output: out, Normalizer: n.Name, Null: !ok})
+			}
+			s = out
+			if !ok {
+				return false
+			}
+		}
+		return true
+	}
+	if kind != KindString && !run(o.Normalizers) {
+		return s, false
+	}
+	return s, run(typed)
+}
+
+// ReportEntry 报告中的一条记录
+type ReportEntry struct {
+	// Path 字段路径，如"items[0].price"，只在DecodeJSON/DecodeYAML中可用
+	Path string
+	// Type strval类型名称
+	Type string
+	// Input 预处理前的输入
+	Input string
+	// Output 预处理后的输出
+	Output string
+	// Normalizer 改写输入的预处理器名称
+	Normalizer string
+	// Null 预处理器是否将输入映射为空值
+	Null bool
+}
+
+// Report 收集解析过程中的预处理记录，可在多个goroutine中并发使用
+type Report struct {
+	mu      sync.Mutex
+	entries []ReportEntry
+}
+
+// Entries 返回已收集记录的副本
+func (r *Report) Entries() []ReportEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]ReportEntry(nil), r.entries...)
+}
+
+// add 追加一条记录，r为nil时忽略
+func (r *Report) add(e ReportEntry) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.entries = append(r.entries, e)
+	r.mu.Unlock()
+}
@@ -0,0 +1,22 @@
+//go:build strval_noyaml
+
+/*
+--------------------------------
+@Create 2026/10/19 02:10
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 02:10
+@Description 不包含YAML支持的构建
+--------------------------------
+使用构建标签strval_noyaml编译时，yaml.go、yamltypes.go与yamlcodec.go均不参与编译，
+各类型不再实现yaml.Marshaler与yaml.Unmarshaler，DecodeYAML与EncodeYAML也不可用，
+程序不会链接gopkg.in/yaml.v3。Options.YAML12等选项仍然存在，但不产生任何效果。
+*/
+
+package strval
+
+// yamlNode 不包含YAML支持时的占位类型，Preserved的原始YAML节点始终为nil
+type yamlNode struct {
+	// Value 与yaml.Node.Value对应，供Preserved.Raw使用
+	Value string
+}
@@ -0,0 +1,179 @@
+/*
+--------------------------------
+@Create 2026/10/18 11:50
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 11:50
+@Description 本地化数字格式解析
+--------------------------------
+本文件实现了带千位分组与不同小数点的数字解析，如"1,234.56"、"1.234,56"、"1 234,56"。
+可以同时配置多种格式，当一个输入在不同格式下得到不同结果时视为歧义并拒绝，而不是猜测。
+*/
+
+package strval
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrAmbiguousNumber 数字在多个已配置的格式下得到不同的结果
+var ErrAmbiguousNumber = errors.New("ambiguous number format")
+
+// NumberFormat 定义数字的小数点与千位分组分隔符
+type NumberFormat struct {
+	// Decimal 小数点字符
+	Decimal rune
+	// Group 允许的千位分组分隔符，可包含多个候选字符（如普通空格与不换行空格），为空表示不允许分组
+	Group string
+}
+
+var (
+	// NumberFormatPoint 以"."为小数点、","为分组符，如"1,234.56"（英文、中文常用）
+	NumberFormatPoint = NumberFormat{Decimal: '.', Group: ","}
+	// NumberFormatComma 以","为小数点、"."为分组符，如"1.234,56"（德语、意大利语等常用）
+	NumberFormatComma = NumberFormat{Decimal: ',', Group: "."}
+	// NumberFormatSpace 以","为小数点、空格为分组符，如"1 234,56"（法语、俄语等常用）
+	NumberFormatSpace = NumberFormat{Decimal: ',', Group: " \u00a0\u202f"}
+)
+
+// canonical 将符合该格式的数字转换为strconv可解析的形式
+// 参数:
+//   - s: 已去除前后空白的输入字符串
+//
+// 返回值:
+//   - string: 以"."为小数点、不含分组符的数字字符串
+//   - bool: 输入是否符合该格式
+//
+// 说明：分组符必须统一，首组1~3位数字，其后每组恰好3位；小数部分不允许分组，可带指数部分
+func (f NumberFormat) canonical(s string) (string, bool) {
+	var b strings.Builder
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		b.WriteByte(s[0])
+		s = s[1:]
+	}
+
+	intPart, fracPart, hasDecimal := strings.Cut(s, string(f.Decimal))
+	if intPart == "" || strings.ContainsRune(fracPart, f.Decimal) {
+		return "", false
+	}
+
+	// 整数部分：校验分组并去除分组符
+	var sep rune
+	groupLen := 0
+	for i, r := range intPart {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+			groupLen++
+		case strings.ContainsRune(f.Group, r):
+			if (sep != 0 && r != sep) || groupLen == 0 || (sep == 0 && groupLen > 3) || (sep != 0 && groupLen != 3) {
+				return "", false
+			}
+			if i+utf8.RuneLen(r) == len(intPart) {
+				return "", false
+			}
+			sep, groupLen = r, 0
+		default:
+			return "", false
+		}
+	}
+	if sep != 0 && groupLen != 3 {
+		return "", false
+	}
+	if !hasDecimal {
+		return b.String(), true
+	}
+
+	// 小数部分：只允许数字与可选的指数部分
+	mantissa, exponent, hasExponent := strings.Cut(strings.ToLower(fracPart), "e")
+	if mantissa == "" || !isDigits(mantissa) {
+		return "", false
+	}
+	b.WriteByte('.')
+	b.WriteString(mantissa)
+	if hasExponent {
+		if exponent != "" && (exponent[0] == '+' || exponent[0] == '-') {
+			b.WriteByte('e')
+			b.WriteByte(exponent[0])
+			exponent = exponent[1:]
+		} else {
+			b.WriteByte('e')
+		}
+		if !isDigits(exponent) {
+			return "", false
+		}
+		b.WriteString(exponent)
+	}
+	return b.String(), true
+}
+
+// hasSeparator 判断字符串中是否包含该格式的小数点或分组符
+func (f NumberFormat) hasSeparator(s string) bool {
+	return strings.ContainsRune(s, f.Decimal) || strings.ContainsAny(s, f.Group)
+}
+
+// isDigits 判断字符串是否全部由ASCII数字组成且非空
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseLocalized 按已配置的数字格式解析字符串
+// 参数:
+//   - s: 输入字符串
+//   - o: 解析选项
+//   - parse: 对规范化后的字符串进行最终解析的函数
+//
+// 返回值:
+//   - T: 解析结果
+//   - bool: 是否由本地化格式处理（未配置格式或输入不含任何分隔符时为false，调用方应按普通语法解析）
+//   - error: 解析过程中的错误
+//
+// 说明：输入在多个格式下均有效且结果不同时返回ErrAmbiguousNumber
+func parseLocalized[T comparable](s string, o *Options, parse func(string) (T, error)) (T, bool, error) {
+	var zero T
+	if len(o.NumberFormats) == 0 {
+		return zero, false, nil
+	}
+	s = strings.TrimSpace(s)
+	handled := false
+	for _, f := range o.NumberFormats {
+		if f.hasSeparator(s) {
+			handled = true
+			break
+		}
+	}
+	if !handled {
+		return zero, false, nil
+	}
+
+	var result T
+	found := false
+	for _, f := range o.NumberFormats {
+		c, ok := f.canonical(s)
+		if !ok {
+			continue
+		}
+		v, err := parse(c)
+		if err != nil {
+			continue
+		}
+		if found && v != result {
+			return zero, true, ErrAmbiguousNumber
+		}
+		result, found = v, true
+	}
+	if !found {
+		return zero, true, errors.New("number does not match any configured format")
+	}
+	return result, true, nil
+}
@@ -0,0 +1,217 @@
+/*
+--------------------------------
+@Create 2026/10/18 09:30
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 09:30
+@Description 全局解析与序列化选项
+--------------------------------
+本文件定义了Options类型，用于集中控制strval各类型在反序列化（JSON/YAML/数据库）
+与序列化时的行为。选项通过SetDefaultOptions全局设置，读取时使用原子操作保证并发安全；
+也可以通过Option在单次DecodeJSON/DecodeYAML/EncodeJSON/EncodeYAML调用中覆盖。
+*/
+
+package strval
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// NonFiniteOutput 定义Float序列化时遇到NaN/±Inf的处理策略
+type NonFiniteOutput int
+
+const (
+	// NonFiniteOutputDefault 保持各格式的原生行为：JSON返回错误，YAML输出.nan/.inf，数据库写入原始浮点数
+	NonFiniteOutputDefault NonFiniteOutput = iota
+	// NonFiniteOutputNull 输出null（数据库写入NULL）
+	NonFiniteOutputNull
+	// NonFiniteOutputString 输出字符串"NaN"、"Infinity"、"-Infinity"
+	NonFiniteOutputString
+	// NonFiniteOutputError 在所有格式中均返回错误
+	NonFiniteOutputError
+)
+
+// NonFiniteInput 定义Float反序列化时遇到NaN/±Inf的处理策略
+type NonFiniteInput int
+
+const (
+	// NonFiniteInputAccept 接受NaN/±Inf，包括"NaN"、"Infinity"、"inf"以及YAML的.nan/.inf形式
+	NonFiniteInputAccept NonFiniteInput = iota
+	// NonFiniteInputReject 拒绝NaN/±Inf，按解析失败处理（置零并记录错误日志）
+	NonFiniteInputReject
+)
+
+// Options 控制strval各类型的解析与序列化行为
+//
+// 零值即为默认行为，与未引入选项前的表现保持一致
+type Options struct {
+	// NonFiniteOutput Float序列化时遇到NaN/±Inf的处理策略
+	NonFiniteOutput NonFiniteOutput
+	// NonFiniteInput Float反序列化时遇到NaN/±Inf的处理策略
+	NonFiniteInput NonFiniteInput
+	// IntSyntax 整数字符串允许的扩展语法（进制前缀、数字分隔符、前导零八进制）
+	IntSyntax IntSyntax
+	// NumberFormats Int/Float允许的本地化数字格式（千位分组与小数点），为空时只接受普通写法
+	NumberFormats []NumberFormat
+	// Unicode 启用的Unicode规范化功能（全角字符、中文数字、中文布尔词）
+	Unicode UnicodeMode
+	// BoolVocabulary Bool/NullBool使用的布尔值词汇表，为nil时使用DefaultBoolVocabulary
+	BoolVocabulary *BoolVocabulary
+	// Normalizers 适用于所有数值与布尔类型的字符串预处理器，按顺序执行
+	Normalizers []Normalizer
+	// TypeNormalizers 只适用于指定类型的字符串预处理器，在Normalizers之后执行
+	TypeNormalizers map[Kind][]Normalizer
+	// Report 用于收集预处理记录的报告，为nil时不收集
+	Report *Report
+	// NumberOutput Int/Float序列化时的输出形式
+	NumberOutput NumberOutput
+	// BoolOutput Bool/NullBool序列化时的输出形式
+	BoolOutput BoolOutput
+	// FloatFormat Float序列化时的数字格式，零值为最短形式
+	FloatFormat FloatFormat
+	// BoolColumn Bool/NullBool写入数据库时的表示形式，零值写入原生bool
+	BoolColumn BoolColumn
+	// YAML12 启用YAML 1.2严格模式：不带引号的on/off等词为字符串，前导零的整数按十进制解析
+	//
+	// 默认与yaml.v3解码到原生类型的结果一致，on/off为布尔值，0755按八进制解析为493
+	YAML12 bool
+
+	// path 当前字段路径，只在设置了Report时由DecodeJSON/DecodeYAML维护
+	path string
+}
+
+// Option 用于在单次解码/编码调用中覆盖全局选项
+type Option func(*Options)
+
+// WithOptions 使用完整的选项替换全局选项
+func WithOptions(o Options) Option {
+	return func(dst *Options) { *dst = o }
+}
+
+// WithNonFiniteOutput 设置Float序列化时遇到NaN/±Inf的处理策略
+func WithNonFiniteOutput(p NonFiniteOutput) Option {
+	return func(o *Options) { o.NonFiniteOutput = p }
+}
+
+// WithNonFiniteInput 设置Float反序列化时遇到NaN/±Inf的处理策略
+func WithNonFiniteInput(p NonFiniteInput) Option {
+	return func(o *Options) { o.NonFiniteInput = p }
+}
+
+// WithIntSyntax 设置整数字符串允许的扩展语法
+func WithIntSyntax(syntax IntSyntax) Option {
+	return func(o *Options) { o.IntSyntax = syntax }
+}
+
+// WithNumberFormats 设置允许的本地化数字格式
+func WithNumberFormats(formats ...NumberFormat) Option {
+	return func(o *Options) { o.NumberFormats = formats }
+}
+
+// WithUnicode 设置启用的Unicode规范化功能
+func WithUnicode(mode UnicodeMode) Option {
+	return func(o *Options) { o.Unicode = mode }
+}
+
+// WithBoolVocabulary 设置布尔值词汇表
+func WithBoolVocabulary(v BoolVocabulary) Option {
+	return func(o *Options) { o.BoolVocabulary = &v }
+}
+
+// WithNormalizers 追加适用于所有数值与布尔类型的字符串预处理器
+func WithNormalizers(normalizers ...Normalizer) Option {
+	return func(o *Options) { o.Normalizers = append(clipNormalizers(o.Normalizers), normalizers...) }
+}
+
+// WithReport 设置用于收集预处理记录的报告
+func WithReport(r *Report) Option {
+	return func(o *Options) { o.Report = r }
+}
+
+// WithNumberOutput 设置Int/Float序列化时的输出形式
+func WithNumberOutput(out NumberOutput) Option {
+	return func(o *Options) { o.NumberOutput = out }
+}
+
+// WithBoolOutput 设置Bool/NullBool序列化时的输出形式
+func WithBoolOutput(out BoolOutput) Option {
+	return func(o *Options) { o.BoolOutput = out }
+}
+
+// WithFloatFormat 设置Float序列化时的数字格式
+func WithFloatFormat(f FloatFormat) Option {
+	return func(o *Options) { o.FloatFormat = f }
+}
+
+// WithYAML12 设置是否启用YAML 1.2严格模式
+func WithYAML12(enabled bool) Option {
+	return func(o *Options) { o.YAML12 = enabled }
+}
+
+// defaultOptions 全局默认选项
+var defaultOptions atomic.Pointer[Options]
+
+func init() {
+	defaultOptions.Store(&Options{})
+}
+
+// SetDefaultOptions 设置全局默认选项
+// 参数:
+//   - o: 新的全局选项
+//
+// 说明：选项按值保存，调用后再修改o不会影响已生效的全局选项
+func SetDefaultOptions(o Options) {
+	defaultOptions.Store(&o)
+}
+
+// DefaultOptions 获取当前的全局默认选项
+// 返回值:
+//   - Options: 当前全局选项的副本
+func DefaultOptions() Options {
+	return *defaultOptions.Load()
+}
+
+// currentOptions 获取当前全局选项的只读指针，供各类型内部使用
+func currentOptions() *Options {
+	return defaultOptions.Load()
+}
+
+// updateDefaultOptions 以原子方式修改全局选项
+func updateDefaultOptions(update func(o *Options)) {
+	for {
+		old := defaultOptions.Load()
+		o := *old
+		update(&o)
+		if defaultOptions.CompareAndSwap(old, &o) {
+			return
+		}
+	}
+}
+
+// at 返回进入子元素后的选项，只在设置了Report时复制选项并记录路径
+// 参数:
+//   - name: 字段名，或以"["开头的下标
+func (o *Options) at(name string) *Options {
+	if o.Report == nil {
+		return o
+	}
+	c := *o
+	if c.path != "" && !strings.HasPrefix(name, "[") {
+		c.path += "."
+	}
+	c.path += name
+	return &c
+}
+
+// resolveOptions 以全局选项为基础应用单次调用的覆盖选项
+func resolveOptions(opts []Option) *Options {
+	if len(opts) == 0 {
+		return currentOptions()
+	}
+	o := DefaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &o
+}
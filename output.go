@@ -0,0 +1,132 @@
+/*
+--------------------------------
+@Create 2026/10/18 17:40
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 17:40
+@Description 可配置的序列化输出形式
+--------------------------------
+默认情况下strval各类型序列化为JSON/YAML的原生类型。部分遗留系统与JavaScript客户端需要
+"maxCount": "100"或"enabled": "Y"这样的写法，本文件定义了数字、布尔值与浮点数格式的输出形式，
+可以通过全局选项、EncodeJSON/EncodeYAML的单次调用选项或字段的strval标签设置。
+*/
+
+package strval
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// NumberOutput 定义Int/Float序列化时的输出形式
+type NumberOutput int
+
+const (
+	// NumberOutputNative 输出原生数值
+	NumberOutputNative NumberOutput = iota
+	// NumberOutputString 输出字符串，如"100"，避免JavaScript等客户端丢失int64精度
+	NumberOutputString
+)
+
+// BoolOutput 定义Bool/NullBool序列化时的输出形式
+type BoolOutput int
+
+const (
+	// BoolOutputNative 输出原生布尔值true/false
+	BoolOutputNative BoolOutput = iota
+	// BoolOutputString 输出字符串"true"/"false"
+	BoolOutputString
+	// BoolOutputYesNo 输出"yes"/"no"
+	BoolOutputYesNo
+	// BoolOutputYN 输出"Y"/"N"
+	BoolOutputYN
+	// BoolOutputNumber 输出数值1/0
+	BoolOutputNumber
+)
+
+// FloatFormat 定义Float序列化时的数字格式，零值为最短形式
+type FloatFormat struct {
+	// Fixed 是否使用固定的小数位数，为false时输出能精确还原原值的最短形式
+	Fixed bool
+	// Decimals 固定的小数位数
+	Decimals int
+}
+
+// FloatShortest 输出能精确还原原值的最短形式，如1.5、0.1
+var FloatShortest = FloatFormat{}
+
+// FloatFixed 输出固定小数位数的形式，如FloatFixed(2)将1.5输出为1.50
+func FloatFixed(decimals int) FloatFormat {
+	return FloatFormat{Fixed: true, Decimals: decimals}
+}
+
+// boolOutputNames 字段标签中bool选项的取值
+var boolOutputNames = map[string]BoolOutput{
+	"native": BoolOutputNative,
+	"string": BoolOutputString,
+	"yesno":  BoolOutputYesNo,
+	"yn":     BoolOutputYN,
+	"number": BoolOutputNumber,
+}
+
+// parseFloatFormat 解析字段标签中decimals选项的取值，"shortest"或非负整数
+func parseFloatFormat(value string) (FloatFormat, error) {
+	if value == "shortest" {
+		return FloatShortest, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return FloatFormat{}, fmt.Errorf("invalid decimals %q", value)
+	}
+	return FloatFixed(n), nil
+}
+
+// boolWords 返回字符串输出形式下的真/假值
+func (o *Options) boolWords() (string, string) {
+	switch o.BoolOutput {
+	case BoolOutputYesNo:
+		return "yes", "no"
+	case BoolOutputYN:
+		return "Y", "N"
+	default:
+		return "true", "false"
+	}
+}
+
+// boolText 返回布尔值在当前输出形式下的文本
+func (o *Options) boolText(b bool) string {
+	if o.BoolOutput == BoolOutputNumber {
+		if b {
+			return "1"
+		}
+		return "0"
+	}
+	t, f := o.boolWords()
+	if b {
+		return t
+	}
+	return f
+}
+
+// boolJSON 按输出形式将布尔值序列化为JSON
+func (o *Options) boolJSON(b bool) ([]byte, error) {
+	switch o.BoolOutput {
+	case BoolOutputNative:
+		return json.Marshal(b)
+	case BoolOutputNumber:
+		return []byte(o.boolText(b)), nil
+	default:
+		return json.Marshal(o.boolText(b))
+	}
+}
+
+// floatText 按浮点数格式返回有限浮点数的文本
+func (o *Options) floatText(v float64) string {
+	if o.FloatFormat.Fixed {
+		return strconv.FormatFloat(v, 'f', o.FloatFormat.Decimals, 64)
+	}
+	// 与encoding/json的数字格式保持一致
+	b, _ := json.Marshal(v)
+	return string(b)
+}
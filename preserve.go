@@ -0,0 +1,130 @@
+/*
+--------------------------------
+@Create 2026/10/18 17:00
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 17:00
+@Description 保留原始表示的往返模式
+--------------------------------
+strval各类型序列化时总是输出规范形式，例如"100"会变为100、"yes"会变为true，
+读取并重新写回配置文件时会产生大量无关的差异。本文件提供Preserved泛型包装类型，
+反序列化时记录原始的JSON字面量或YAML节点（含引号风格与注释），只要值没有被修改，
+序列化时就原样输出；值被修改后按原始的引号风格输出新值。
+*/
+
+package strval
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+)
+
+// Preservable 可以包装为Preserved的strval类型
+type Preservable interface {
+	Bool | NullBool | Int | Float | String
+}
+
+// Preserved 保留原始表示的strval值，用于读取后需要原样写回的场景
+//
+// 示例:
+//
+//	type Config struct {
+//		Port  strval.Preserved[strval.Int]  `json:"port" yaml:"port"`
+//		Debug strval.Preserved[strval.Bool] `json:"debug" yaml:"debug"`
+//	}
+//
+// 读取`port: "8080"`后未修改Port时写回仍为`port: "8080"`；修改为9090后写回`port: "9090"`。
+type Preserved[T Preservable] struct {
+	// Val 解析后的值，可以直接读取或修改
+	Val T
+
+	// orig 反序列化时解析得到的值，用于判断Val是否被修改
+	orig T
+	// raw 原始的JSON字面量
+	raw []byte
+	// node 原始的YAML节点，构建标签strval_noyaml下始终为nil
+	node *yamlNode
+}
+
+// NewPreserved 创建不带原始表示的Preserved值，序列化时与T的行为一致
+func NewPreserved[T Preservable](v T) Preserved[T] {
+	return Preserved[T]{Val: v}
+}
+
+// GetValue 获取包装的strval值
+func (p Preserved[T]) GetValue() T {
+	return p.Val
+}
+
+// Modified 判断值在反序列化之后是否被修改，没有原始表示时返回true
+func (p Preserved[T]) Modified() bool {
+	if p.raw == nil && p.node == nil {
+		return true
+	}
+	return !sameValue(p.Val, p.orig)
+}
+
+// Raw 返回原始的JSON字面量或YAML标量文本，没有原始表示时返回空字符串
+func (p Preserved[T]) Raw() string {
+	switch {
+	case p.raw != nil:
+		return string(p.raw)
+	case p.node != nil:
+		return p.node.Value
+	default:
+		return ""
+	}
+}
+
+// MarshalJSON 实现json.Marshaler接口，值未修改时输出原始字面量
+// 返回值:
+//   - []byte: 序列化后的JSON字节
+//   - error: 序列化过程中的错误
+//
+// 说明：值被修改且原始字面量为JSON字符串时，新值同样以字符串形式输出
+func (p Preserved[T]) MarshalJSON() ([]byte, error) {
+	return p.marshalJSON(currentOptions())
+}
+
+// marshalJSON 按指定选项序列化为JSON，供MarshalJSON与EncodeJSON共用
+func (p Preserved[T]) marshalJSON(o *Options) ([]byte, error) {
+	if p.raw != nil && !p.Modified() {
+		return p.raw, nil
+	}
+	out, err := any(p.Val).(optionMarshaler).marshalJSON(o)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.raw) > 0 && p.raw[0] == '"' && out[0] != '"' && string(out) != "null" {
+		return json.Marshal(string(out))
+	}
+	return out, nil
+}
+
+// UnmarshalJSON 实现json.Unmarshaler接口，解析规则与T相同，同时记录原始字面量
+// 参数:
+//   - data: JSON数据字节
+//
+// 返回值:
+//   - error: 反序列化过程中的错误
+func (p *Preserved[T]) UnmarshalJSON(data []byte) error {
+	p.unmarshalJSON(data, currentOptions())
+	return nil
+}
+
+// unmarshalJSON 按指定选项从JSON数据反序列化，供UnmarshalJSON与DecodeJSON共用
+func (p *Preserved[T]) unmarshalJSON(data []byte, o *Options) {
+	any(&p.Val).(optionUnmarshaler).unmarshalJSON(data, o)
+	p.orig = p.Val
+	p.raw = bytes.Clone(bytes.TrimSpace(data))
+	p.node = nil
+}
+
+// sameValue 判断两个值是否相同，Float按位比较以便NaN与自身相同
+func sameValue[T Preservable](a, b T) bool {
+	if fa, ok := any(a).(Float); ok {
+		return math.Float64bits(float64(fa)) == math.Float64bits(float64(any(b).(Float)))
+	}
+	return a == b
+}
@@ -0,0 +1,35 @@
+/*
+--------------------------------
+@Create 2026/10/19 01:50
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 01:50
+@Description Scan的兼容规则
+--------------------------------
+统一的转换规则中，Bool与NullBool只接受整数0与1，Int只接受小数部分为零的浮点数。早期版本的Scan更宽松，
+已有的数据库数据可能依赖这些规则，收紧后会在升级时被静默置零，因此Scan保留以下例外：
+  - Bool与NullBool将任意非零整数读取为true，如TINYINT列中的2
+  - Int将范围内的浮点数截断为整数，如42.5得到42，NaN、±Inf与超出范围的值仍为无效值
+
+这些例外只在Scan中生效，在交给coerce之前调整标量，JSON、YAML与文本仍使用统一的规则。
+*/
+
+package strval
+
+import "math"
+
+// scanBoolScalar 按Scan的兼容规则调整将要转换为Bool或NullBool的标量，任意整数按是否为零转换为布尔值
+func scanBoolScalar(v scalar) scalar {
+	if v.kind == scalarInt {
+		return scalar{kind: scalarBool, b: v.i != 0}
+	}
+	return v
+}
+
+// scanIntScalar 按Scan的兼容规则调整将要转换为Int的标量，范围内的浮点数截断小数部分
+func scanIntScalar(v scalar) scalar {
+	if v.kind == scalarFloat && v.f >= math.MinInt && v.f < math.MaxInt {
+		return scalar{kind: scalarInt, i: int64(v.f)}
+	}
+	return v
+}
@@ -11,6 +11,8 @@
 1. 支持从字符串形式的JSON/YAML值反序列化为对应的基本类型
 2. 提供友好的错误处理机制，当解析失败时返回零值并记录错误日志
 3. 序列化为JSON/YAML时保持原始类型格式
+
+YAML相关的方法位于yamltypes.go，使用构建标签strval_noyaml编译时不包含。
 */
 
 package strval
@@ -21,9 +23,6 @@ import (
 	"fmt"
 	"log/slog"
 	"strconv"
-	"strings"
-
-	"gopkg.in/yaml.v3"
 )
 
 // StringValuer 定义所有字符串值包装类型的共同泛型接口
@@ -39,8 +38,15 @@ type Bool bool
 // 返回值:
 //   - []byte: 序列化后的JSON字节
 //   - error: 序列化过程中的错误
+//
+// 说明：输出形式由全局选项BoolOutput控制，默认输出true/false
 func (b Bool) MarshalJSON() ([]byte, error) {
-	return json.Marshal(bool(b))
+	return b.marshalJSON(currentOptions())
+}
+
+// marshalJSON 按指定选项序列化为JSON，供MarshalJSON与EncodeJSON共用
+func (b Bool) marshalJSON(o *Options) ([]byte, error) {
+	return o.boolJSON(bool(b))
 }
 
 // UnmarshalJSON 实现json.Unmarshaler接口，支持从JSON布尔值或字符串反序列化为Bool
@@ -51,45 +57,39 @@ func (b Bool) MarshalJSON() ([]byte, error) {
 //   - error: 反序列化过程中的错误
 //
 // 说明:
-//   - 支持直接解析JSON布尔值
+//   - 支持直接解析JSON布尔值，以及数值0与1
 //   - 支持解析字符串形式的布尔值（如"true"、"false"、"yes"、"no"、"1"、"0"）
 //   - 解析失败时返回false并记录错误日志
 func (b *Bool) UnmarshalJSON(data []byte) error {
-	// 尝试直接解析为bool
-	var boolVal bool
-	if err := json.Unmarshal(data, &boolVal); err == nil {
-		*b = Bool(boolVal)
-		return nil
+	b.unmarshalJSON(data, currentOptions())
+	return nil
+}
+
+// unmarshalJSON 按指定选项从JSON数据反序列化，供UnmarshalJSON与DecodeJSON共用
+func (b *Bool) unmarshalJSON(data []byte, o *Options) {
+	if err := b.coerce(jsonScalar(data), o); err != nil {
+		slog.Error("invalid Bool value", "error", err)
 	}
+}
 
-	// 尝试解析为字符串
-	var strVal string
-	if err := json.Unmarshal(data, &strVal); err != nil {
+// setString 预处理后解析字符串形式的bool值，供各反序列化路径共用
+// 返回值:
+//   - error: 解析失败时的错误，此时值被置为false；预处理器将输入映射为空值时置为false且不返回错误
+func (b *Bool) setString(s string, o *Options) error {
+	s, valid := o.normalize(KindBool, s)
+	if !valid {
 		*b = false
-		slog.Error("invalid Bool value: not a bool or string", "error", err)
 		return nil
 	}
-
-	// 解析字符串形式的bool值
-	boolVal, err2 := parseBool(strVal)
-	if err2 != nil {
+	v, err := parseBool(s, o)
+	if err != nil {
 		*b = false
-		slog.Error("invalid Bool string value", "value", strVal, "error", err2)
-		return nil
+		return err
 	}
-
-	*b = Bool(boolVal)
+	*b = Bool(v)
 	return nil
 }
 
-// MarshalYAML 实现yaml.Marshaler接口，将Bool序列化为YAML布尔值
-// 返回值:
-//   - interface{}: 序列化后的值
-//   - error: 序列化过程中的错误
-func (b Bool) MarshalYAML() (interface{}, error) {
-	return bool(b), nil
-}
-
 // GetValue 实现StringValuer[bool]接口，获取包装的原始布尔值
 // 返回值:
 //   - bool: 原始的bool值
@@ -101,8 +101,10 @@ func (b Bool) GetValue() bool {
 // 返回值:
 //   - driver.Value: 数据库可接受的值
 //   - error: 转换过程中的错误
+//
+// 说明：写入的值由全局选项BoolColumn控制，默认写入原生bool
 func (b Bool) Value() (driver.Value, error) {
-	return b.GetValue(), nil
+	return currentOptions().BoolColumn.value(b.GetValue()), nil
 }
 
 // Scan 实现sql.Scanner接口，用于数据库读取操作
@@ -111,77 +113,12 @@ func (b Bool) Value() (driver.Value, error) {
 //
 // 返回值:
 //   - error: 扫描过程中的错误
-func (b *Bool) Scan(value interface{}) error {
-	if value == nil {
-		*b = false
-		return nil
-	}
-
-	// 尝试直接转换为bool
-	if boolVal, ok := value.(bool); ok {
-		*b = Bool(boolVal)
-		return nil
-	}
-
-	// 尝试从int转换
-	if intVal, ok := value.(int64); ok {
-		*b = Bool(intVal != 0)
-		return nil
-	}
-
-	// 尝试从string转换
-	if strVal, ok := value.(string); ok {
-		boolVal, err := parseBool(strVal)
-		if err != nil {
-			*b = false
-			slog.Error("invalid Bool value from database", "value", strVal, "error", err)
-			return nil
-		}
-		*b = Bool(boolVal)
-		return nil
-	}
-
-	*b = false
-	slog.Error("unsupported Bool value type from database", "type", fmt.Sprintf("%T", value))
-	return nil
-}
-
-// UnmarshalYAML 实现yaml.Unmarshaler接口，支持从YAML布尔值或字符串反序列化为Bool
-// 参数:
-//   - node: YAML节点
 //
-// 返回值:
-//   - error: 反序列化过程中的错误
-//
-// 说明:
-//   - 支持直接解析YAML布尔值
-//   - 支持解析字符串形式的布尔值
-//   - 解析失败时返回false并记录错误日志
-func (b *Bool) UnmarshalYAML(node *yaml.Node) error {
-	// 尝试直接解析为bool
-	var boolVal bool
-	if err := node.Decode(&boolVal); err == nil {
-		*b = Bool(boolVal)
-		return nil
-	}
-
-	// 尝试解析为字符串
-	var strVal string
-	if err := node.Decode(&strVal); err != nil {
-		*b = false
-		slog.Error("invalid Bool value: not a bool or string", "error", err)
-		return nil
-	}
-
-	// 解析字符串形式的bool值
-	boolVal, err2 := parseBool(strVal)
-	if err2 != nil {
-		*b = false
-		slog.Error("invalid Bool string value", "value", strVal, "error", err2)
-		return nil
-	}
-
-	*b = Bool(boolVal)
+// 说明：任意非零整数读取为true，与早期版本一致；MySQL的BIT(1)列返回的[]byte{0}/[]byte{1}同样接受。
+// 全局选项BoolColumn为字符对时先匹配该字符对，保证Value写入的值可以读回
+func (b *Bool) Scan(value interface{}) error {
+	o := currentOptions()
+	b.scan(value, o.BoolColumn, o)
 	return nil
 }
 
@@ -192,7 +129,17 @@ type Int int
 // 返回值:
 //   - []byte: 序列化后的JSON字节
 //   - error: 序列化过程中的错误
+//
+// 说明：全局选项NumberOutput为NumberOutputString时输出字符串
 func (i Int) MarshalJSON() ([]byte, error) {
+	return i.marshalJSON(currentOptions())
+}
+
+// marshalJSON 按指定选项序列化为JSON，供MarshalJSON与EncodeJSON共用
+func (i Int) marshalJSON(o *Options) ([]byte, error) {
+	if o.NumberOutput == NumberOutputString {
+		return json.Marshal(strconv.Itoa(int(i)))
+	}
 	return json.Marshal(int(i))
 }
 
@@ -204,45 +151,40 @@ func (i Int) MarshalJSON() ([]byte, error) {
 //   - error: 反序列化过程中的错误
 //
 // 说明:
-//   - 支持直接解析JSON数值
-//   - 支持解析字符串形式的整数值
+//   - 支持直接解析JSON数值，小数部分为零的数值（如1.0、1e2）同样按整数解析
+//   - 支持解析字符串形式的整数值，允许前后空白与前导"+"号
+//   - 进制前缀、数字分隔符等扩展语法由全局选项IntSyntax控制
 //   - 解析失败时返回0并记录错误日志
 func (i *Int) UnmarshalJSON(data []byte) error {
-	// 尝试直接解析为int
-	var intVal int
-	if err := json.Unmarshal(data, &intVal); err == nil {
-		*i = Int(intVal)
-		return nil
+	i.unmarshalJSON(data, currentOptions())
+	return nil
+}
+
+// unmarshalJSON 按指定选项从JSON数据反序列化，供UnmarshalJSON与DecodeJSON共用
+func (i *Int) unmarshalJSON(data []byte, o *Options) {
+	if err := i.coerce(jsonScalar(data), o); err != nil {
+		slog.Error("invalid Int value", "error", err)
 	}
+}
 
-	// 尝试解析为字符串
-	var strVal string
-	if err := json.Unmarshal(data, &strVal); err != nil {
+// setString 预处理后解析字符串形式的int值，供各反序列化路径共用
+// 返回值:
+//   - error: 解析失败时的错误，此时值被置为0；预处理器将输入映射为空值时置为0且不返回错误
+func (i *Int) setString(s string, o *Options) error {
+	s, valid := o.normalize(KindInt, s)
+	if !valid {
 		*i = 0
-		slog.Error("invalid Int value: not an int or string", "error", err)
 		return nil
 	}
-
-	// 解析字符串形式的int值
-	intVal, err2 := strconv.Atoi(strVal)
-	if err2 != nil {
+	v, err := parseInt(s, 0, o)
+	if err != nil {
 		*i = 0
-		slog.Error("invalid Int string value", "value", strVal, "error", err2)
-		return nil
+		return err
 	}
-
-	*i = Int(intVal)
+	*i = Int(v)
 	return nil
 }
 
-// MarshalYAML 实现yaml.Marshaler接口，将Int序列化为YAML数值
-// 返回值:
-//   - interface{}: 序列化后的值
-//   - error: 序列化过程中的错误
-func (i Int) MarshalYAML() (interface{}, error) {
-	return int(i), nil
-}
-
 // GetValue 实现StringValuer[int]接口，获取包装的原始整数值
 // 返回值:
 //   - int: 原始的int值
@@ -264,77 +206,12 @@ func (i Int) Value() (driver.Value, error) {
 //
 // 返回值:
 //   - error: 扫描过程中的错误
-func (i *Int) Scan(value interface{}) error {
-	if value == nil {
-		*i = 0
-		return nil
-	}
-
-	// 尝试直接转换为int64
-	if int64Val, ok := value.(int64); ok {
-		*i = Int(int64Val)
-		return nil
-	}
-
-	// 尝试从float转换
-	if floatVal, ok := value.(float64); ok {
-		*i = Int(floatVal)
-		return nil
-	}
-
-	// 尝试从string转换
-	if strVal, ok := value.(string); ok {
-		intVal, err := strconv.Atoi(strVal)
-		if err != nil {
-			*i = 0
-			slog.Error("invalid Int value from database", "value", strVal, "error", err)
-			return nil
-		}
-		*i = Int(intVal)
-		return nil
-	}
-
-	*i = 0
-	slog.Error("unsupported Int value type from database", "type", fmt.Sprintf("%T", value))
-	return nil
-}
-
-// UnmarshalYAML 实现yaml.Unmarshaler接口，支持从YAML数值或字符串反序列化为Int
-// 参数:
-//   - node: YAML节点
 //
-// 返回值:
-//   - error: 反序列化过程中的错误
-//
-// 说明:
-//   - 支持直接解析YAML数值
-//   - 支持解析字符串形式的整数值
-//   - 解析失败时返回0并记录错误日志
-func (i *Int) UnmarshalYAML(node *yaml.Node) error {
-	// 尝试直接解析为int
-	var intVal int
-	if err := node.Decode(&intVal); err == nil {
-		*i = Int(intVal)
-		return nil
-	}
-
-	// 尝试解析为字符串
-	var strVal string
-	if err := node.Decode(&strVal); err != nil {
-		*i = 0
-		slog.Error("invalid Int value: not an int or string", "error", err)
-		return nil
-	}
-
-	// 解析字符串形式的int值
-	intVal, err2 := strconv.Atoi(strVal)
-	if err2 != nil {
-		*i = 0
-		slog.Error("invalid Int string value", "value", strVal, "error", err2)
-		return nil
+// 说明：浮点数截断小数部分，与早期版本一致；时间取Unix时间戳（秒）
+func (i *Int) Scan(value interface{}) error {
+	if err := i.coerce(scanIntScalar(sqlScalar(value)), currentOptions()); err != nil {
+		slog.Error("invalid Int value from database", "error", err)
 	}
-
-	*i = Int(intVal)
 	return nil
 }
 
@@ -345,8 +222,30 @@ type Float float64
 // 返回值:
 //   - []byte: 序列化后的JSON字节
 //   - error: 序列化过程中的错误
+//
+// 说明:
+//   - NaN/±Inf按全局选项NonFiniteOutput处理，默认与encoding/json一致返回错误
+//   - 数字格式与输出形式由全局选项FloatFormat与NumberOutput控制
 func (f Float) MarshalJSON() ([]byte, error) {
-	return json.Marshal(float64(f))
+	return f.marshalJSON(currentOptions())
+}
+
+// marshalJSON 按指定选项序列化为JSON，供MarshalJSON与EncodeJSON共用
+func (f Float) marshalJSON(o *Options) ([]byte, error) {
+	v := float64(f)
+	if isNonFinite(v) {
+		switch o.NonFiniteOutput {
+		case NonFiniteOutputNull:
+			return []byte("null"), nil
+		case NonFiniteOutputString:
+			return json.Marshal(nonFiniteString(v))
+		}
+		return json.Marshal(v)
+	}
+	if o.NumberOutput == NumberOutputString {
+		return json.Marshal(o.floatText(v))
+	}
+	return []byte(o.floatText(v)), nil
 }
 
 // UnmarshalJSON 实现json.Unmarshaler接口，支持从JSON数值或字符串反序列化为Float
@@ -361,41 +260,35 @@ func (f Float) MarshalJSON() ([]byte, error) {
 //   - 支持解析字符串形式的浮点数值
 //   - 解析失败时返回0并记录错误日志
 func (f *Float) UnmarshalJSON(data []byte) error {
-	// 尝试直接解析为float64
-	var floatVal float64
-	if err := json.Unmarshal(data, &floatVal); err == nil {
-		*f = Float(floatVal)
-		return nil
+	f.unmarshalJSON(data, currentOptions())
+	return nil
+}
+
+// unmarshalJSON 按指定选项从JSON数据反序列化，供UnmarshalJSON与DecodeJSON共用
+func (f *Float) unmarshalJSON(data []byte, o *Options) {
+	if err := f.coerce(jsonScalar(data), o); err != nil {
+		slog.Error("invalid Float value", "error", err)
 	}
+}
 
-	// 尝试解析为字符串
-	var strVal string
-	if err := json.Unmarshal(data, &strVal); err != nil {
+// setString 预处理后解析字符串形式的float值，供各反序列化路径共用
+// 返回值:
+//   - error: 解析失败时的错误，此时值被置为0；预处理器将输入映射为空值时置为0且不返回错误
+func (f *Float) setString(s string, o *Options) error {
+	s, valid := o.normalize(KindFloat, s)
+	if !valid {
 		*f = 0
-		slog.Error("invalid Float value: not a float or string", "error", err)
 		return nil
 	}
-
-	// 解析字符串形式的float值
-	floatVal, err2 := strconv.ParseFloat(strVal, 64)
-	if err2 != nil {
+	v, err := parseFloat(s, o)
+	if err != nil {
 		*f = 0
-		slog.Error("invalid Float string value", "value", strVal, "error", err2)
-		return nil
+		return err
 	}
-
-	*f = Float(floatVal)
+	*f = Float(v)
 	return nil
 }
 
-// MarshalYAML 实现yaml.Marshaler接口，将Float序列化为YAML数值
-// 返回值:
-//   - interface{}: 序列化后的值
-//   - error: 序列化过程中的错误
-func (f Float) MarshalYAML() (interface{}, error) {
-	return float64(f), nil
-}
-
 // GetValue 实现StringValuer[float64]接口，获取包装的原始浮点值
 // 返回值:
 //   - float64: 原始的float64值
@@ -407,8 +300,21 @@ func (f Float) GetValue() float64 {
 // 返回值:
 //   - driver.Value: 数据库可接受的值
 //   - error: 转换过程中的错误
+//
+// 说明：NaN/±Inf按全局选项NonFiniteOutput处理，默认写入原始浮点数
 func (f Float) Value() (driver.Value, error) {
-	return f.GetValue(), nil
+	v := f.GetValue()
+	if isNonFinite(v) {
+		switch currentOptions().NonFiniteOutput {
+		case NonFiniteOutputNull:
+			return nil, nil
+		case NonFiniteOutputString:
+			return nonFiniteString(v), nil
+		case NonFiniteOutputError:
+			return nil, fmt.Errorf("strval: unsupported Float value %s", nonFiniteString(v))
+		}
+	}
+	return v, nil
 }
 
 // Scan 实现sql.Scanner接口，用于数据库读取操作
@@ -417,103 +323,28 @@ func (f Float) Value() (driver.Value, error) {
 //
 // 返回值:
 //   - error: 扫描过程中的错误
-func (f *Float) Scan(value interface{}) error {
-	if value == nil {
-		*f = 0
-		return nil
-	}
-
-	// 尝试直接转换为float64
-	if floatVal, ok := value.(float64); ok {
-		*f = Float(floatVal)
-		return nil
-	}
-
-	// 尝试从int转换
-	if intVal, ok := value.(int64); ok {
-		*f = Float(intVal)
-		return nil
-	}
-
-	// 尝试从string转换
-	if strVal, ok := value.(string); ok {
-		floatVal, err := strconv.ParseFloat(strVal, 64)
-		if err != nil {
-			*f = 0
-			slog.Error("invalid Float value from database", "value", strVal, "error", err)
-			return nil
-		}
-		*f = Float(floatVal)
-		return nil
-	}
-
-	*f = 0
-	slog.Error("unsupported Float value type from database", "type", fmt.Sprintf("%T", value))
-	return nil
-}
-
-// UnmarshalYAML 实现yaml.Unmarshaler接口，支持从YAML数值或字符串反序列化为Float
-// 参数:
-//   - node: YAML节点
-//
-// 返回值:
-//   - error: 反序列化过程中的错误
 //
-// 说明:
-//   - 支持直接解析YAML数值
-//   - 支持解析字符串形式的浮点数值
-//   - 解析失败时返回0并记录错误日志
-func (f *Float) UnmarshalYAML(node *yaml.Node) error {
-	// 尝试直接解析为float64
-	var floatVal float64
-	if err := node.Decode(&floatVal); err == nil {
-		*f = Float(floatVal)
-		return nil
-	}
-
-	// 尝试解析为字符串
-	var strVal string
-	if err := node.Decode(&strVal); err != nil {
-		*f = 0
-		slog.Error("invalid Float value: not a float or string", "error", err)
-		return nil
-	}
-
-	// 解析字符串形式的float值
-	floatVal, err2 := strconv.ParseFloat(strVal, 64)
-	if err2 != nil {
-		*f = 0
-		slog.Error("invalid Float string value", "value", strVal, "error", err2)
-		return nil
+// 说明：NaN/±Inf按全局选项NonFiniteInput处理，与UnmarshalJSON的规则相同；时间取带小数的Unix时间戳（秒）
+func (f *Float) Scan(value interface{}) error {
+	if err := f.coerce(sqlScalar(value), currentOptions()); err != nil {
+		slog.Error("invalid Float value from database", "error", err)
 	}
-
-	*f = Float(floatVal)
 	return nil
 }
 
 // parseBool 解析字符串形式的布尔值
 // 参数:
 //   - s: 输入字符串
+//   - o: 解析选项
 //
 // 返回值:
-//   - bool: 解析后的布尔值
+//   - bool: 解析后的布尔值，命中未知值词汇时为false
 //   - error: 解析过程中的错误
 //
-// 支持的值:
-//   - 真值: "true", "yes", "y", "1"
-//   - 假值: "false", "no", "n", "0"
-//
-// 所有值不区分大小写，会自动去除前后空格
-func parseBool(s string) (bool, error) {
-	s = strings.ToLower(strings.TrimSpace(s))
-	switch s {
-	case "true", "yes", "y", "1":
-		return true, nil
-	case "false", "no", "n", "0":
-		return false, nil
-	default:
-		return false, fmt.Errorf("cannot parse '%s' as bool", s)
-	}
+// 说明：按Options.BoolVocabulary匹配，未配置时使用DefaultBoolVocabulary
+func parseBool(s string, o *Options) (bool, error) {
+	v, _, err := parseTriBool(s, o)
+	return v, err
 }
 
 // String 增强的字符串类型，确保序列化后总是字符串格式
@@ -526,6 +357,11 @@ type String string
 //
 // 说明：确保序列化后总是字符串格式，即使原始值是数值等其他类型
 func (s String) MarshalJSON() ([]byte, error) {
+	return s.marshalJSON(currentOptions())
+}
+
+// marshalJSON 按指定选项序列化为JSON，供MarshalJSON与EncodeJSON共用，输出形式不受选项影响
+func (s String) marshalJSON(_ *Options) ([]byte, error) {
 	return json.Marshal(string(s))
 }
 
@@ -536,49 +372,31 @@ func (s String) MarshalJSON() ([]byte, error) {
 // 返回值:
 //   - error: 反序列化过程中的错误
 //
-// 说明：支持从字符串、数值、布尔值等类型反序列化为字符串
+// 说明：支持从字符串、数值、布尔值等类型反序列化为字符串，数值保留JSON中的写法（如1.50）
 func (s *String) UnmarshalJSON(data []byte) error {
-	// 尝试直接解析为string
-	var strVal string
-	if err := json.Unmarshal(data, &strVal); err == nil {
-		*s = String(strVal)
-		return nil
-	}
-
-	// 尝试解析为int
-	var intVal int
-	if err := json.Unmarshal(data, &intVal); err == nil {
-		*s = String(strconv.Itoa(intVal))
-		return nil
-	}
+	s.unmarshalJSON(data, currentOptions())
+	return nil
+}
 
-	// 尝试解析为float64
-	var floatVal float64
-	if err := json.Unmarshal(data, &floatVal); err == nil {
-		*s = String(strconv.FormatFloat(floatVal, 'g', -1, 64))
-		return nil
+// unmarshalJSON 按指定选项从JSON数据反序列化，供UnmarshalJSON与DecodeJSON共用
+func (s *String) unmarshalJSON(data []byte, o *Options) {
+	if err := s.coerce(jsonScalar(data), o); err != nil {
+		slog.Error("invalid String value", "error", err)
 	}
+}
 
-	// 尝试解析为bool
-	var boolVal bool
-	if err := json.Unmarshal(data, &boolVal); err == nil {
-		*s = String(strconv.FormatBool(boolVal))
-		return nil
+// setString 预处理后设置字符串值，预处理器映射为空值时设置为空字符串
+//
+// 说明：字符串总能设置成功，返回error只为与其他类型的setString保持一致
+func (s *String) setString(str string, o *Options) error {
+	str, valid := o.normalize(KindString, str)
+	if !valid {
+		str = ""
 	}
-
-	*s = ""
-	slog.Error("invalid String value", "error", "cannot parse to string")
+	*s = String(str)
 	return nil
 }
 
-// MarshalYAML 实现yaml.Marshaler接口，将String序列化为YAML字符串
-// 返回值:
-//   - interface{}: 序列化后的值
-//   - error: 序列化过程中的错误
-func (s String) MarshalYAML() (interface{}, error) {
-	return string(s), nil
-}
-
 // GetValue 实现StringValuer[string]接口，获取包装的原始字符串值
 // 返回值:
 //   - string: 原始的string值
@@ -600,73 +418,114 @@ func (s String) Value() (driver.Value, error) {
 //
 // 返回值:
 //   - error: 扫描过程中的错误
+//
+// 说明：数值按最短形式格式化，时间按RFC 3339格式化，不支持的类型得到空字符串并记录错误日志
 func (s *String) Scan(value interface{}) error {
-	if value == nil {
-		*s = ""
-		return nil
+	if err := s.coerce(sqlScalar(value), currentOptions()); err != nil {
+		slog.Error("invalid String value from database", "error", err)
 	}
+	return nil
+}
 
-	// 尝试直接转换为string
-	if strVal, ok := value.(string); ok {
-		*s = String(strVal)
-		return nil
-	}
+// NullBool 可为空的增强布尔类型，支持true/false/未知三态值
+//
+// null、空字符串以及BoolVocabulary.Unknown中的词汇均解析为无效值（Valid为false）。
+// 注意yaml.v3遇到null时不会调用UnmarshalYAML，yaml.Unmarshal会保留字段原值，DecodeYAML则会置为无效值
+type NullBool struct {
+	// Bool 布尔值，Valid为false时无意义
+	Bool bool
+	// Valid 是否为已知的真/假值
+	Valid bool
+}
 
-	// 尝试从其他类型转换为string
-	switch v := value.(type) {
-	case int64:
-		*s = String(strconv.FormatInt(v, 10))
-		return nil
-	case float64:
-		*s = String(strconv.FormatFloat(v, 'g', -1, 64))
-		return nil
-	case bool:
-		*s = String(strconv.FormatBool(v))
-		return nil
-	default:
-		*s = String(fmt.Sprintf("%v", v))
-		slog.Warn("converting non-standard type to String", "type", fmt.Sprintf("%T", v))
-	}
+// MarshalJSON 实现json.Marshaler接口，将NullBool序列化为JSON布尔值，无效值序列化为null
+// 返回值:
+//   - []byte: 序列化后的JSON字节
+//   - error: 序列化过程中的错误
+//
+// 说明：有效值的输出形式由全局选项BoolOutput控制
+func (n NullBool) MarshalJSON() ([]byte, error) {
+	return n.marshalJSON(currentOptions())
+}
 
-	return nil
+// marshalJSON 按指定选项序列化为JSON，供MarshalJSON与EncodeJSON共用
+func (n NullBool) marshalJSON(o *Options) ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return o.boolJSON(n.Bool)
 }
 
-// UnmarshalYAML 实现yaml.Unmarshaler接口，支持从YAML字符串或其他类型反序列化为String
+// UnmarshalJSON 实现json.Unmarshaler接口，支持从JSON布尔值、字符串或null反序列化为NullBool
 // 参数:
-//   - node: YAML节点
+//   - data: JSON数据字节
 //
 // 返回值:
 //   - error: 反序列化过程中的错误
-func (s *String) UnmarshalYAML(node *yaml.Node) error {
-	// 尝试直接解析为string
-	var strVal string
-	if err := node.Decode(&strVal); err == nil {
-		*s = String(strVal)
-		return nil
-	}
+//
+// 说明:
+//   - null、空字符串与未知值词汇得到无效值
+//   - 数值0与1分别解析为false与true
+//   - 解析失败时返回无效值并记录错误日志
+func (n *NullBool) UnmarshalJSON(data []byte) error {
+	n.unmarshalJSON(data, currentOptions())
+	return nil
+}
 
-	// 尝试解析为int
-	var intVal int
-	if err := node.Decode(&intVal); err == nil {
-		*s = String(strconv.Itoa(intVal))
-		return nil
+// unmarshalJSON 按指定选项从JSON数据反序列化，供UnmarshalJSON与DecodeJSON共用
+func (n *NullBool) unmarshalJSON(data []byte, o *Options) {
+	if err := n.coerce(jsonScalar(data), o); err != nil {
+		slog.Error("invalid NullBool value", "error", err)
 	}
+}
 
-	// 尝试解析为float64
-	var floatVal float64
-	if err := node.Decode(&floatVal); err == nil {
-		*s = String(strconv.FormatFloat(floatVal, 'g', -1, 64))
+// setString 预处理后解析字符串形式的三态布尔值，供各反序列化路径共用
+// 返回值:
+//   - error: 解析失败时的错误，此时值被置为无效值
+func (n *NullBool) setString(s string, o *Options) error {
+	*n = NullBool{}
+	s, valid := o.normalize(KindNullBool, s)
+	if !valid || s == "" {
 		return nil
 	}
+	boolVal, valid, err := parseTriBool(s, o)
+	if err != nil {
+		return err
+	}
+	*n = NullBool{Bool: boolVal, Valid: valid}
+	return nil
+}
 
-	// 尝试解析为bool
-	var boolVal bool
-	if err := node.Decode(&boolVal); err == nil {
-		*s = String(strconv.FormatBool(boolVal))
-		return nil
+// GetValue 实现StringValuer[bool]接口，获取包装的布尔值，无效值返回false
+// 返回值:
+//   - bool: 布尔值
+func (n NullBool) GetValue() bool {
+	return n.Valid && n.Bool
+}
+
+// Value 实现driver.Valuer接口，用于数据库写入操作，无效值写入NULL
+// 返回值:
+//   - driver.Value: 数据库可接受的值
+//   - error: 转换过程中的错误
+//
+// 说明：有效值写入的值由全局选项BoolColumn控制，默认写入原生bool
+func (n NullBool) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
 	}
+	return currentOptions().BoolColumn.value(n.Bool), nil
+}
 
-	*s = ""
-	slog.Error("invalid String value in YAML", "error", "cannot parse to string")
+// Scan 实现sql.Scanner接口，用于数据库读取操作
+// 参数:
+//   - value: 从数据库读取的值
+//
+// 返回值:
+//   - error: 扫描过程中的错误
+//
+// 说明：NULL得到无效值，任意非零整数读取为true；全局选项BoolColumn为字符对时先匹配该字符对
+func (n *NullBool) Scan(value interface{}) error {
+	o := currentOptions()
+	n.scan(value, o.BoolColumn, o)
 	return nil
 }
@@ -0,0 +1,246 @@
+/*
+--------------------------------
+@Create 2026/10/19 01:10
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 01:10
+@Description JSON反序列化的内存分配与性能基准
+--------------------------------
+本文件包含UnmarshalJSON常见路径零分配的测试，以及strval字段与原生int/bool/float64/string字段的基准对比。
+*/
+
+package strval
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// TestUnmarshalJSONAllocs 测试数字、布尔值与不含转义的字符串字面量不分配内存
+func TestUnmarshalJSONAllocs(t *testing.T) {
+	withOptions(t, Options{})
+
+	var (
+		b Bool
+		i Int
+		f Float
+		n NullBool
+	)
+	tests := []struct {
+		name  string
+		input string
+		fn    func(data []byte) error
+	}{
+		{"Bool", `true`, b.UnmarshalJSON},
+		{"Bool", `"yes"`, b.UnmarshalJSON},
+		{"Int", `8080`, i.UnmarshalJSON},
+		{"Int", `" 8080 "`, i.UnmarshalJSON},
+		{"Float", `-1.25e3`, f.UnmarshalJSON},
+		{"Float", `"0.5"`, f.UnmarshalJSON},
+		{"NullBool", `null`, n.UnmarshalJSON},
+		{"NullBool", `"n"`, n.UnmarshalJSON},
+	}
+	for _, tt := range tests {
+		data := []byte(tt.input)
+		if allocs := testing.AllocsPerRun(100, func() { _ = tt.fn(data) }); allocs != 0 {
+			t.Errorf("%s.UnmarshalJSON(%s) allocs = %v, want 0", tt.name, tt.input, allocs)
+		}
+	}
+
+	// String只分配结果本身
+	var s String
+	data := []byte(`"hello"`)
+	if allocs := testing.AllocsPerRun(100, func() { _ = s.UnmarshalJSON(data) }); allocs > 1 {
+		t.Errorf("String.UnmarshalJSON allocs = %v, want <= 1", allocs)
+	}
+}
+
+// retainHandler 保留日志记录而不立即格式化的处理器，模拟异步或缓冲输出的日志处理器
+type retainHandler struct {
+	records *[]slog.Record
+}
+
+func (h retainHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h retainHandler) Handle(_ context.Context, r slog.Record) error {
+	*h.records = append(*h.records, r.Clone())
+	return nil
+}
+
+func (h retainHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h retainHandler) WithGroup(string) slog.Handler { return h }
+
+// TestUnmarshalJSONErrorCopied 测试日志中的错误不引用调用方的JSON缓冲区
+func TestUnmarshalJSONErrorCopied(t *testing.T) {
+	withOptions(t, Options{})
+	var records []slog.Record
+	old := slog.Default()
+	slog.SetDefault(slog.New(retainHandler{&records}))
+	t.Cleanup(func() { slog.SetDefault(old) })
+
+	var (
+		i Int
+		f Float
+	)
+	for _, fn := range []func([]byte) error{i.UnmarshalJSON, f.UnmarshalJSON, i.UnmarshalText, f.UnmarshalText} {
+		records = records[:0]
+		data := []byte(`"12x"`)
+		if err := fn(data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// 调用方复用缓冲区
+		copy(data, `"99y"`)
+		if len(records) != 1 {
+			t.Fatalf("expected one log record, got %d", len(records))
+		}
+		records[0].Attrs(func(a slog.Attr) bool {
+			if a.Key == "error" && !strings.Contains(a.Value.String(), "12x") {
+				t.Errorf("logged error aliases the input buffer: %s", a.Value)
+			}
+			return true
+		})
+	}
+}
+
+// TestUnmarshalJSONLiterals 测试直接解析与json.Unmarshal的语义一致
+func TestUnmarshalJSONLiterals(t *testing.T) {
+	withOptions(t, Options{})
+
+	var i Int
+	for input, want := range map[string]Int{`42`: 42, ` -7 `: -7, `1.5`: 0, `1e2`: 100, `9223372036854775808`: 0, `"0042"`: 42, `null`: 0, `true`: 0, `[1]`: 0, `"42"`: 42} {
+		i = 99
+		_ = i.UnmarshalJSON([]byte(input))
+		if i != want {
+			t.Errorf("Int.UnmarshalJSON(%s) = %d, want %d", input, i, want)
+		}
+	}
+	var f Float
+	for input, want := range map[string]Float{`1e2`: 100, `-0.5`: -0.5, `1e400`: 0, `-inf`: 0, `01`: 0, `"1e2"`: 100} {
+		f = 99
+		_ = f.UnmarshalJSON([]byte(input))
+		if f != want {
+			t.Errorf("Float.UnmarshalJSON(%s) = %v, want %v", input, f, want)
+		}
+	}
+	var s String
+	for input, want := range map[string]String{`"a\"b"`: `a"b`, `12`: "12", `1.50`: "1.50", `1e3`: "1e3", `1e400`: "1e400", `false`: "false", `null`: "", "\"\xff\"": "�", `{}`: ""} {
+		_ = s.UnmarshalJSON([]byte(input))
+		if s != want {
+			t.Errorf("String.UnmarshalJSON(%s) = %q, want %q", input, s, want)
+		}
+	}
+
+	// String保留的字符串不能引用输入数据
+	data := []byte(`"abc"`)
+	_ = s.UnmarshalJSON(data)
+	data[1] = 'x'
+	if s != "abc" {
+		t.Errorf("String aliases input data: %q", s)
+	}
+}
+
+type benchNative struct {
+	ID     int     `json:"id"`
+	Active bool    `json:"active"`
+	Score  float64 `json:"score"`
+	Name   string  `json:"name"`
+}
+
+type benchStrval struct {
+	ID     Int    `json:"id"`
+	Active Bool   `json:"active"`
+	Score  Float  `json:"score"`
+	Name   String `json:"name"`
+}
+
+var (
+	benchNativeJSON = []byte(`{"id":12345,"active":true,"score":98.5,"name":"event"}`)
+	benchStringJSON = []byte(`{"id":"12345","active":"yes","score":"98.5","name":"event"}`)
+)
+
+// BenchmarkUnmarshalJSON 对比原生字段与strval字段的反序列化
+func BenchmarkUnmarshalJSON(b *testing.B) {
+	b.Run("native", func(b *testing.B) {
+		b.ReportAllocs()
+		var v benchNative
+		for range b.N {
+			if err := json.Unmarshal(benchNativeJSON, &v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("strval", func(b *testing.B) {
+		b.ReportAllocs()
+		var v benchStrval
+		for range b.N {
+			if err := json.Unmarshal(benchNativeJSON, &v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("strval-strings", func(b *testing.B) {
+		b.ReportAllocs()
+		var v benchStrval
+		for range b.N {
+			if err := json.Unmarshal(benchStringJSON, &v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkIntUnmarshalJSON 对比int与Int的字面量解析
+func BenchmarkIntUnmarshalJSON(b *testing.B) {
+	number, str := []byte(`12345`), []byte(`"12345"`)
+	b.Run("int", func(b *testing.B) {
+		b.ReportAllocs()
+		var v int
+		for range b.N {
+			_ = json.Unmarshal(number, &v)
+		}
+	})
+	b.Run("Int", func(b *testing.B) {
+		b.ReportAllocs()
+		var v Int
+		for range b.N {
+			_ = v.UnmarshalJSON(number)
+		}
+	})
+	b.Run("Int-string", func(b *testing.B) {
+		b.ReportAllocs()
+		var v Int
+		for range b.N {
+			_ = v.UnmarshalJSON(str)
+		}
+	})
+}
+
+// BenchmarkBoolUnmarshalJSON 对比bool与Bool的字面量解析
+func BenchmarkBoolUnmarshalJSON(b *testing.B) {
+	literal, str := []byte(`true`), []byte(`"yes"`)
+	b.Run("bool", func(b *testing.B) {
+		b.ReportAllocs()
+		var v bool
+		for range b.N {
+			_ = json.Unmarshal(literal, &v)
+		}
+	})
+	b.Run("Bool", func(b *testing.B) {
+		b.ReportAllocs()
+		var v Bool
+		for range b.N {
+			_ = v.UnmarshalJSON(literal)
+		}
+	})
+	b.Run("Bool-string", func(b *testing.B) {
+		b.ReportAllocs()
+		var v Bool
+		for range b.N {
+			_ = v.UnmarshalJSON(str)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+//go:build !strval_noyaml
+
+/*
+--------------------------------
+@Create 2026/10/18 14:50
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 14:50
+@Description 布尔值词汇表与NullBool测试
+--------------------------------
+本文件包含对布尔值词汇表（全局注册、单次调用、字段标签）以及NullBool三态值的测试。
+*/
+
+package strval
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestBoolVocabularyDefault 测试默认词汇表与早期版本保持一致
+func TestBoolVocabularyDefault(t *testing.T) {
+	o := &Options{}
+	for _, s := range []string{"true", "YES", " y ", "1"} {
+		if v, err := parseBool(s, o); err != nil || !v {
+			t.Errorf("parseBool(%q) = %v, %v; want true", s, v, err)
+		}
+	}
+	for _, s := range []string{"false", "No", "N", "0"} {
+		if v, err := parseBool(s, o); err != nil || v {
+			t.Errorf("parseBool(%q) = %v, %v; want false", s, v, err)
+		}
+	}
+	for _, s := range []string{"on", "off", "t", "enabled"} {
+		if _, err := parseBool(s, o); err == nil {
+			t.Errorf("parseBool(%q) should fail with the default vocabulary", s)
+		}
+	}
+}
+
+// TestBoolVocabularyGlobal 测试全局注册词汇
+func TestBoolVocabularyGlobal(t *testing.T) {
+	withOptions(t, Options{})
+	RegisterBoolTokens([]string{"on", "enabled"}, []string{"off", "disabled"})
+	RegisterUnknownBoolTokens("n/a")
+
+	var b Bool
+	if err := json.Unmarshal([]byte(`"ON"`), &b); err != nil || !b {
+		t.Errorf("JSON \"ON\": got %v, err %v", b, err)
+	}
+	if err := yaml.Unmarshal([]byte(`disabled`), &b); err != nil || b {
+		t.Errorf("YAML disabled: got %v, err %v", b, err)
+	}
+	if err := b.Scan("enabled"); err != nil || !b {
+		t.Errorf("Scan(\"enabled\"): got %v, err %v", b, err)
+	}
+	// 默认词汇仍然有效
+	if err := json.Unmarshal([]byte(`"yes"`), &b); err != nil || !b {
+		t.Errorf("JSON \"yes\": got %v, err %v", b, err)
+	}
+	// 未知值对Bool得到false
+	if err := json.Unmarshal([]byte(`"n/a"`), &b); err != nil || b {
+		t.Errorf("JSON \"n/a\": got %v, err %v", b, err)
+	}
+}
+
+// TestBoolVocabularyPerCallAndTag 测试单次调用与字段标签指定的词汇表
+func TestBoolVocabularyPerCallAndTag(t *testing.T) {
+	type Flags struct {
+		Legacy  Bool     `json:"legacy" yaml:"legacy" strval:"true=T|Y,false=F|N"`
+		Feature Bool     `json:"feature" yaml:"feature"`
+		Opt     NullBool `json:"opt" yaml:"opt" strval:"unknown=?|unknown"`
+	}
+
+	var f Flags
+	data := []byte(`{"legacy":"t","feature":"enabled","opt":"?"}`)
+	if err := DecodeJSON(data, &f, WithBoolVocabulary(ExtendedBoolVocabulary)); err != nil {
+		t.Fatalf("DecodeJSON failed: %v", err)
+	}
+	if !bool(f.Legacy) || !bool(f.Feature) || f.Opt.Valid {
+		t.Errorf("unexpected values: %+v", f)
+	}
+
+	// 字段标签替换了真值列表，"yes"不再被Legacy字段接受
+	if err := DecodeYAML([]byte("legacy: \"yes\"\nfeature: off\nopt: \"on\"\n"), &f, WithBoolVocabulary(ExtendedBoolVocabulary)); err != nil {
+		t.Fatalf("DecodeYAML failed: %v", err)
+	}
+	if bool(f.Legacy) || bool(f.Feature) || !f.Opt.Valid || !f.Opt.Bool {
+		t.Errorf("unexpected values: %+v", f)
+	}
+
+	type BadTag struct {
+		B Bool `json:"b" strval:"maybe=x"`
+	}
+	var bad BadTag
+	if err := DecodeJSON([]byte(`{"b":"x"}`), &bad); err == nil {
+		t.Errorf("expected error for unknown tag option")
+	}
+}
+
+// TestNullBool 测试NullBool三态值在JSON、YAML与数据库路径中的行为
+func TestNullBool(t *testing.T) {
+	withOptions(t, Options{BoolVocabulary: &BoolVocabulary{
+		True:    []string{"Y"},
+		False:   []string{"N"},
+		Unknown: []string{"U"},
+	}})
+
+	tests := []struct {
+		input string
+		want  NullBool
+	}{
+		{`"Y"`, NullBool{Bool: true, Valid: true}},
+		{`"n"`, NullBool{Bool: false, Valid: true}},
+		{`"U"`, NullBool{}},
+		{`""`, NullBool{}},
+		{`null`, NullBool{}},
+		{`true`, NullBool{Bool: true, Valid: true}},
+		{`"bad"`, NullBool{}},
+	}
+	for _, tt := range tests {
+		n := NullBool{Bool: true, Valid: true}
+		if err := json.Unmarshal([]byte(tt.input), &n); err != nil || n != tt.want {
+			t.Errorf("JSON %s: got %+v, err %v; want %+v", tt.input, n, err, tt.want)
+		}
+		// yaml.v3遇到null时不调用UnmarshalYAML，因此从零值开始
+		n = NullBool{}
+		if err := yaml.Unmarshal([]byte(tt.input), &n); err != nil || n != tt.want {
+			t.Errorf("YAML %s: got %+v, err %v; want %+v", tt.input, n, err, tt.want)
+		}
+	}
+
+	data, err := json.Marshal([]NullBool{{Bool: true, Valid: true}, {}})
+	if err != nil || string(data) != "[true,null]" {
+		t.Errorf("JSON marshal: got %s, err %v", data, err)
+	}
+	ydata, err := yaml.Marshal(map[string]NullBool{"a": {}})
+	if err != nil || string(ydata) != "a: null\n" {
+		t.Errorf("YAML marshal: got %q, err %v", ydata, err)
+	}
+
+	var n NullBool
+	if err := n.Scan("U"); err != nil || n.Valid {
+		t.Errorf("Scan(\"U\"): got %+v, err %v", n, err)
+	}
+	if err := n.Scan(int64(1)); err != nil || !n.Valid || !n.Bool {
+		t.Errorf("Scan(1): got %+v, err %v", n, err)
+	}
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("Scan(nil): got %+v, err %v", n, err)
+	}
+	if v, err := (NullBool{}).Value(); err != nil || v != nil {
+		t.Errorf("Value(): got %v, err %v; want nil", v, err)
+	}
+	if v, err := (NullBool{Bool: true, Valid: true}).Value(); err != nil || v != true {
+		t.Errorf("Value(): got %v, err %v; want true", v, err)
+	}
+}
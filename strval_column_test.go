@@ -0,0 +1,152 @@
+/*
+--------------------------------
+@Create 2026/10/19 03:10
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 03:10
+@Description Bool/NullBool数据库表示形式的测试
+--------------------------------
+本文件测试全局选项BoolColumn与ColumnBool/ColumnNullBool字段包装类型写入的值，以及经Scan读回的结果。
+*/
+
+package strval
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+)
+
+// customColumn 测试用的自定义字符对
+type customColumn struct{}
+
+func (customColumn) BoolColumn() BoolColumn {
+	return BoolColumn{Kind: BoolColumnChars, True: "J", False: "N"}
+}
+
+// TestBoolColumnValue 测试全局选项BoolColumn控制写入的值并可经Scan读回
+func TestBoolColumnValue(t *testing.T) {
+	tests := []struct {
+		column    BoolColumn
+		wantTrue  driver.Value
+		wantFalse driver.Value
+	}{
+		{BoolColumn{}, true, false},
+		{BoolColumnOneZero, int64(1), int64(0)},
+		{BoolColumnYN, "Y", "N"},
+		{BoolColumnTF, "T", "F"},
+	}
+
+	for _, tt := range tests {
+		withOptions(t, Options{BoolColumn: tt.column})
+		for _, b := range []bool{true, false} {
+			want := tt.wantFalse
+			if b {
+				want = tt.wantTrue
+			}
+			got, err := Bool(b).Value()
+			if err != nil || got != want {
+				t.Errorf("%+v: Bool(%t).Value() = %#v, %v, want %#v", tt.column, b, got, err, want)
+			}
+			var back Bool
+			if err := back.Scan(got); err != nil || bool(back) != b {
+				t.Errorf("%+v: Bool.Scan(%#v) = %t, %v, want %t", tt.column, got, back, err, b)
+			}
+
+			got, err = NullBool{Bool: b, Valid: true}.Value()
+			if err != nil || got != want {
+				t.Errorf("%+v: NullBool(%t).Value() = %#v, %v, want %#v", tt.column, b, got, err, want)
+			}
+			var nb NullBool
+			if err := nb.Scan(got); err != nil || nb != (NullBool{Bool: b, Valid: true}) {
+				t.Errorf("%+v: NullBool.Scan(%#v) = %+v, %v", tt.column, got, nb, err)
+			}
+		}
+		if got, _ := (NullBool{}).Value(); got != nil {
+			t.Errorf("%+v: invalid NullBool.Value() = %#v, want nil", tt.column, got)
+		}
+	}
+}
+
+// TestBoolColumnScan 测试字符对的匹配不区分大小写并忽略CHAR(n)补齐的空格，未命中时按词汇表解析
+func TestBoolColumnScan(t *testing.T) {
+	withOptions(t, Options{
+		BoolColumn:     BoolColumnTF,
+		BoolVocabulary: &BoolVocabulary{True: []string{"Y"}, False: []string{"N"}},
+	})
+
+	tests := []struct {
+		value interface{}
+		want  Bool
+	}{
+		{"T", true},
+		{"f", false},
+		{[]byte("T "), true},
+		{"Y", true},
+		{"N", false},
+		{int64(1), true},
+		{"X", false},
+	}
+	for _, tt := range tests {
+		b := Bool(!tt.want)
+		if err := b.Scan(tt.value); err != nil || b != tt.want {
+			t.Errorf("Bool.Scan(%#v) = %v, %v, want %v", tt.value, b, err, tt.want)
+		}
+	}
+}
+
+// TestColumnBool 测试字段包装类型使用自身的表示形式，不受全局选项影响
+func TestColumnBool(t *testing.T) {
+	withOptions(t, Options{BoolColumn: BoolColumnOneZero})
+
+	yn := ColumnBool[ColumnYN]{Bool: true}
+	if got, err := yn.Value(); err != nil || got != "Y" {
+		t.Errorf("ColumnBool[ColumnYN].Value() = %#v, %v, want \"Y\"", got, err)
+	}
+	custom := ColumnBool[customColumn]{}
+	if got, err := custom.Value(); err != nil || got != "N" {
+		t.Errorf("ColumnBool[customColumn].Value() = %#v, %v, want \"N\"", got, err)
+	}
+	if err := custom.Scan([]byte("j")); err != nil || !bool(custom.Bool) {
+		t.Errorf("ColumnBool[customColumn].Scan(\"j\") = %v, %v, want true", custom.Bool, err)
+	}
+
+	var tf ColumnNullBool[ColumnTF]
+	if got, err := tf.Value(); err != nil || got != nil {
+		t.Errorf("invalid ColumnNullBool.Value() = %#v, %v, want nil", got, err)
+	}
+	if err := tf.Scan("F"); err != nil || tf.NullBool != (NullBool{Valid: true}) {
+		t.Errorf("ColumnNullBool[ColumnTF].Scan(\"F\") = %+v, %v", tf.NullBool, err)
+	}
+	if got, err := tf.Value(); err != nil || got != "F" {
+		t.Errorf("ColumnNullBool[ColumnTF].Value() = %#v, %v, want \"F\"", got, err)
+	}
+	if err := tf.Scan(nil); err != nil || tf.Valid {
+		t.Errorf("ColumnNullBool.Scan(nil) = %+v, %v, want invalid", tf.NullBool, err)
+	}
+}
+
+// TestColumnBoolJSON 测试字段包装类型的JSON行为与Bool相同
+func TestColumnBoolJSON(t *testing.T) {
+	withOptions(t, Options{})
+
+	var cfg struct {
+		Active ColumnBool[ColumnYN]     `json:"active"`
+		Known  ColumnNullBool[ColumnTF] `json:"known"`
+	}
+	if err := json.Unmarshal([]byte(`{"active":"yes","known":null}`), &cfg); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if !bool(cfg.Active.Bool) || cfg.Known.Valid {
+		t.Errorf("Unmarshal got %+v", cfg)
+	}
+	out, err := json.Marshal(cfg)
+	if err != nil || string(out) != `{"active":true,"known":null}` {
+		t.Errorf("Marshal = %s, %v", out, err)
+	}
+
+	cfg.Active.Bool = false
+	if err := DecodeJSON([]byte(`{"active":"1"}`), &cfg); err != nil || !bool(cfg.Active.Bool) {
+		t.Errorf("DecodeJSON got %+v, %v", cfg, err)
+	}
+}
@@ -0,0 +1,172 @@
+/*
+--------------------------------
+@Create 2026/10/19 01:40
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 01:40
+@Description 各格式转换规则的一致性测试
+--------------------------------
+本文件包含一张跨格式的一致性表：同一个逻辑输入分别以JSON、YAML、文本与数据库驱动值的形式
+解码到各strval类型，断言各格式接受的输入相同，得到的值也相同。
+本文件也提供各测试共用的withOptions，不依赖YAML，在构建标签strval_noyaml下同样运行。
+*/
+
+package strval
+
+import (
+	"testing"
+)
+
+// withOptions 在测试期间临时替换全局选项，测试结束后自动恢复
+func withOptions(t *testing.T, o Options) {
+	t.Helper()
+	old := DefaultOptions()
+	SetDefaultOptions(o)
+	t.Cleanup(func() { SetDefaultOptions(old) })
+}
+
+// noSQL 表示该输入没有对应的数据库驱动值
+type noSQL struct{}
+
+// conformanceValues 同一个输入解码到各strval类型的结果
+type conformanceValues struct {
+	B Bool
+	I Int
+	F Float
+	S String
+	N NullBool
+}
+
+// conformanceCase 一个逻辑输入在各格式中的写法
+type conformanceCase struct {
+	name string
+	json string      // JSON字面量，为空时跳过
+	yaml string      // YAML文档，为空时跳过
+	text interface{} // UnmarshalText的输入，nil时跳过
+	sql  interface{} // Scan的输入，noSQL{}时跳过
+	want conformanceValues
+}
+
+var conformanceCases = []conformanceCase{
+	{"null", `null`, `~`, nil, nil, conformanceValues{}},
+	{"true", `true`, `true`, nil, true, conformanceValues{B: true, S: "true", N: NullBool{Bool: true, Valid: true}}},
+	{"false", `false`, `false`, nil, false, conformanceValues{S: "false", N: NullBool{Valid: true}}},
+	{"int 0", `0`, `0`, nil, int64(0), conformanceValues{S: "0", N: NullBool{Valid: true}}},
+	{"int 1", `1`, `1`, nil, int64(1), conformanceValues{B: true, I: 1, F: 1, S: "1", N: NullBool{Bool: true, Valid: true}}},
+	{"int 2", `2`, `2`, nil, int64(2), conformanceValues{I: 2, F: 2, S: "2"}},
+	{"int -7", `-7`, `-7`, nil, int64(-7), conformanceValues{I: -7, F: -7, S: "-7"}},
+	{"float 1.5", `1.5`, `1.5`, nil, 1.5, conformanceValues{F: 1.5, S: "1.5"}},
+	// 数据库驱动值没有原始写法，String按最短形式格式化
+	{"float 2.0", `2.0`, `2.0`, nil, noSQL{}, conformanceValues{I: 2, F: 2, S: "2.0"}},
+	{"float 2", ``, ``, nil, float64(2), conformanceValues{I: 2, F: 2, S: "2"}},
+	{"int overflow", `9223372036854775808`, `9223372036854775808`, nil, noSQL{}, conformanceValues{F: 9223372036854775808, S: "9223372036854775808"}},
+	{"string yes", `"yes"`, `"yes"`, "yes", "yes", conformanceValues{B: true, S: "yes", N: NullBool{Bool: true, Valid: true}}},
+	{"string 0", `"0"`, `"0"`, "0", "0", conformanceValues{S: "0", N: NullBool{Valid: true}}},
+	{"string padded int", `" 8080 "`, `" 8080 "`, " 8080 ", " 8080 ", conformanceValues{I: 8080, F: 8080, S: " 8080 "}},
+	{"string float", `"0.5"`, `"0.5"`, "0.5", "0.5", conformanceValues{F: 0.5, S: "0.5"}},
+	{"string empty", `""`, `""`, "", "", conformanceValues{}},
+	{"string invalid", `"maybe"`, `maybe`, "maybe", "maybe", conformanceValues{S: "maybe"}},
+	{"unsupported", `[1]`, `[1]`, nil, []string{"1"}, conformanceValues{}},
+}
+
+// scanExceptions 按scancompat.go中的兼容规则，Scan与其他格式结果不同的输入
+var scanExceptions = map[string]conformanceValues{
+	"int 2":     {B: true, I: 2, F: 2, S: "2", N: NullBool{Bool: true, Valid: true}},
+	"int -7":    {B: true, I: -7, F: -7, S: "-7", N: NullBool{Bool: true, Valid: true}},
+	"float 1.5": {I: 1, F: 1.5, S: "1.5"},
+}
+
+// conformanceSentinel 解码前的初始值，用于确认解析失败时各类型被置为零值
+var conformanceSentinel = conformanceValues{B: true, I: 99, F: 99, S: "x", N: NullBool{Bool: true, Valid: true}}
+
+// conformanceDecode 按指定格式解码输入，格式不适用时返回false
+func conformanceDecode(t *testing.T, format string, c conformanceCase) (conformanceValues, bool) {
+	t.Helper()
+	got := conformanceSentinel
+	targets := []interface{}{&got.B, &got.I, &got.F, &got.S, &got.N}
+	switch format {
+	case "JSON":
+		if c.json == "" {
+			return got, false
+		}
+		for _, v := range targets {
+			if err := v.(interface{ UnmarshalJSON([]byte) error }).UnmarshalJSON([]byte(c.json)); err != nil {
+				t.Errorf("%s: UnmarshalJSON(%s) into %T error: %v", c.name, c.json, v, err)
+			}
+		}
+	case "YAML":
+		if c.yaml == "" || !conformanceYAML(t, c, targets) {
+			return got, false
+		}
+	case "Text":
+		text, ok := c.text.(string)
+		if !ok {
+			return got, false
+		}
+		for _, v := range targets {
+			if err := v.(interface{ UnmarshalText([]byte) error }).UnmarshalText([]byte(text)); err != nil {
+				t.Errorf("%s: UnmarshalText(%q) into %T error: %v", c.name, text, v, err)
+			}
+		}
+	case "SQL":
+		if _, ok := c.sql.(noSQL); ok {
+			return got, false
+		}
+		for _, v := range targets {
+			if err := v.(interface{ Scan(interface{}) error }).Scan(c.sql); err != nil {
+				t.Errorf("%s: Scan(%#v) into %T error: %v", c.name, c.sql, v, err)
+			}
+		}
+	}
+	return got, true
+}
+
+// TestConformance 测试同一个输入在各格式中被同样地接受或拒绝，Scan的例外见scanExceptions
+func TestConformance(t *testing.T) {
+	withOptions(t, Options{})
+
+	for _, c := range conformanceCases {
+		formats := 0
+		for _, format := range []string{"JSON", "YAML", "Text", "SQL"} {
+			got, ok := conformanceDecode(t, format, c)
+			if !ok {
+				continue
+			}
+			formats++
+			want := c.want
+			if e, ok := scanExceptions[c.name]; ok && format == "SQL" {
+				want = e
+			}
+			if got != want {
+				t.Errorf("%s via %s: got %+v, want %+v", c.name, format, got, want)
+			}
+		}
+		if formats == 0 {
+			t.Errorf("%s: no format applies", c.name)
+		}
+	}
+}
+
+// TestConformanceOptions 测试选项对各格式同样生效
+func TestConformanceOptions(t *testing.T) {
+	withOptions(t, Options{
+		NonFiniteInput: NonFiniteInputReject,
+		BoolVocabulary: &BoolVocabulary{True: []string{"Y"}, False: []string{"N"}},
+	})
+
+	cases := []conformanceCase{
+		// 自定义词汇表不影响整数0与1
+		{"int 1", `1`, `1`, nil, int64(1), conformanceValues{B: true, I: 1, F: 1, S: "1", N: NullBool{Bool: true, Valid: true}}},
+		{"string Y", `"Y"`, `"Y"`, "Y", "Y", conformanceValues{B: true, S: "Y", N: NullBool{Bool: true, Valid: true}}},
+		{"string yes", `"yes"`, `"yes"`, "yes", "yes", conformanceValues{S: "yes"}},
+		{"string NaN", `"NaN"`, `"NaN"`, "NaN", "NaN", conformanceValues{S: "NaN"}},
+		{"float inf", ``, `.inf`, nil, noSQL{}, conformanceValues{S: ".inf"}},
+	}
+	for _, c := range cases {
+		for _, format := range []string{"JSON", "YAML", "Text", "SQL"} {
+			if got, ok := conformanceDecode(t, format, c); ok && got != c.want {
+				t.Errorf("%s via %s: got %+v, want %+v", c.name, format, got, c.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,171 @@
+/*
+--------------------------------
+@Create 2026/10/18 22:10
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 22:10
+@Description CSV读取与写入测试
+--------------------------------
+本文件包含对CSVReader逐行读取、单元格错误报告以及CSVWriter输出格式的测试。
+*/
+
+package strval
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+type csvBase struct {
+	ID Int `csv:"id"`
+}
+
+type csvProduct struct {
+	csvBase
+	Name    String        `csv:"name"`
+	Price   Float         `csv:"price" strval:"decimals=2"`
+	OnSale  Bool          `csv:"on_sale" strval:"bool=yn"`
+	Stock   *Int          `csv:"stock"`
+	Rating  NullBool      `csv:"rated"`
+	Weight  float64       `csv:"weight"`
+	Lead    time.Duration `csv:"lead"`
+	Comment string        `csv:"-"`
+}
+
+const csvInput = `ID,name,price,on_sale,stock,rated,extra,lead
+1,Apple,1.5,yes,10,,x,1h
+2,Pear,abc,maybe,,Y,y,2h
+3,"Plum, red",0.25,N,oops,n,z,soon
+`
+
+// TestCSVReader 测试按表头映射字段以及逐行读取
+func TestCSVReader(t *testing.T) {
+	withOptions(t, Options{})
+
+	r := NewCSVReader(strings.NewReader(csvInput))
+	var p csvProduct
+	if err := r.Read(&p); err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+	if p.ID != 1 || p.Name != "Apple" || p.Price != 1.5 || !bool(p.OnSale) || p.Stock == nil || *p.Stock != 10 ||
+		p.Rating.Valid || p.Lead != time.Hour {
+		t.Errorf("unexpected row 1: %+v", p)
+	}
+
+	err := r.Read(&p)
+	var cellErr *CSVError
+	if !errors.As(err, &cellErr) {
+		t.Fatalf("expected CSVError, got %v", err)
+	}
+	if cellErr.Line != 3 || cellErr.Column != 8 || cellErr.Header != "price" || cellErr.Value != "abc" {
+		t.Errorf("unexpected CSVError %+v", cellErr)
+	}
+	if !strings.Contains(err.Error(), "column 12 (on_sale)") {
+		t.Errorf("error should report on_sale: %v", err)
+	}
+	// 出错的单元格置为零值，其余字段仍然有效，上一行的值不会残留
+	if p.ID != 2 || p.Name != "Pear" || p.Price != 0 || bool(p.OnSale) || p.Stock != nil || !p.Rating.Valid {
+		t.Errorf("unexpected row 2: %+v", p)
+	}
+
+	if err := r.Read(&p); err == nil || p.Name != "Plum, red" {
+		t.Errorf("row 3 = %+v, %v", p, err)
+	}
+	if err := r.Read(&p); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+	if header, _ := r.Header(); len(header) != 8 || header[0] != "ID" {
+		t.Errorf("unexpected header %v", header)
+	}
+}
+
+// TestCSVReaderAll 测试ReadAll汇总所有行的错误
+func TestCSVReaderAll(t *testing.T) {
+	withOptions(t, Options{})
+
+	var products []csvProduct
+	err := NewCSVReader(strings.NewReader(csvInput)).ReadAll(&products)
+	if len(products) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(products))
+	}
+	for _, want := range []string{"line 3, column 8 (price)", "line 3, column 12 (on_sale)",
+		"line 4, column 22 (stock)", "line 4, column 31 (lead)"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("error should contain %q: %v", want, err)
+		}
+	}
+
+	// CSV格式错误立即返回
+	err = NewCSVReader(strings.NewReader("id,name\n1,\"bad\n")).ReadAll(&products)
+	var cellErr *CSVError
+	if err == nil || errors.As(err, &cellErr) {
+		t.Errorf("expected csv parse error, got %v", err)
+	}
+}
+
+// TestCSVReaderOptions 测试单次调用选项
+func TestCSVReaderOptions(t *testing.T) {
+	withOptions(t, Options{})
+
+	r := NewCSVReader(strings.NewReader("id;price\n0x1F;\"1.234,5\"\n"),
+		WithIntSyntax(IntBasePrefix), WithNumberFormats(NumberFormatComma))
+	r.Reader.Comma = ';'
+	var p csvProduct
+	if err := r.Read(&p); err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+	if p.ID != 31 || p.Price != 1234.5 {
+		t.Errorf("unexpected row: %+v", p)
+	}
+}
+
+// TestCSVWriter 测试表头与布尔值、数字的输出格式
+func TestCSVWriter(t *testing.T) {
+	withOptions(t, Options{})
+
+	stock := Int(5)
+	products := []csvProduct{
+		{csvBase: csvBase{ID: 1}, Name: "Apple", Price: 1.5, OnSale: true, Stock: &stock, Weight: 0.25, Lead: time.Hour},
+		{csvBase: csvBase{ID: 2}, Name: "Plum, red", Price: 2, Rating: NullBool{Bool: false, Valid: true}, Comment: "x"},
+	}
+	var buf bytes.Buffer
+	if err := NewCSVWriter(&buf, WithBoolOutput(BoolOutputYesNo)).WriteAll(products); err != nil {
+		t.Fatalf("WriteAll error: %v", err)
+	}
+	want := `id,name,price,on_sale,stock,rated,weight,lead
+1,Apple,1.50,Y,5,,0.25,1h0m0s
+2,"Plum, red",2.00,N,,no,0,0s
+`
+	if buf.String() != want {
+		t.Errorf("WriteAll =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	var back []csvProduct
+	if err := NewCSVReader(&buf).ReadAll(&back); err != nil {
+		t.Fatalf("ReadAll error: %v", err)
+	}
+	if len(back) != 2 || back[0].Price != 1.5 || !bool(back[0].OnSale) || back[1].Rating.Bool || !back[1].Rating.Valid {
+		t.Errorf("round trip mismatch: %+v", back)
+	}
+}
+
+// TestCSVWriterErrors 测试类型错误
+func TestCSVWriterErrors(t *testing.T) {
+	w := NewCSVWriter(io.Discard)
+	if err := w.Write(1); err == nil {
+		t.Errorf("expected error for non-struct")
+	}
+	if err := w.Write(csvBase{}); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	if err := w.Write(csvProduct{}); err == nil {
+		t.Errorf("expected error for mixed types")
+	}
+	if err := NewCSVWriter(io.Discard).Write(struct{ C chan int }{}); err == nil {
+		t.Errorf("expected unsupported type error")
+	}
+}
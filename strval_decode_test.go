@@ -0,0 +1,148 @@
+//go:build !strval_noyaml
+
+/*
+--------------------------------
+@Create 2026/10/18 12:20
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 12:20
+@Description DecodeJSON/DecodeYAML测试
+--------------------------------
+本文件包含对按调用指定选项反序列化的测试，验证嵌套结构体、指针、切片、映射、嵌入结构体
+等形态下strval字段都能使用本次调用的选项，且其余字段行为与encoding/json、yaml.v3一致。
+*/
+
+package strval
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// decodeBase 用于测试嵌入结构体字段提升
+type decodeBase struct {
+	ID   Int    `json:"id" yaml:"id"`
+	Note string `json:"note" yaml:"note"`
+}
+
+// decodeTarget 覆盖各种字段形态的测试结构体
+type decodeTarget struct {
+	decodeBase `yaml:",inline"`
+	Name       string          `json:"name" yaml:"name"`
+	Count      Int             `json:"count" yaml:"count"`
+	Ptr        *Int            `json:"ptr" yaml:"ptr"`
+	List       []Int           `json:"list" yaml:"list"`
+	Pair       [2]Float        `json:"pair" yaml:"pair"`
+	Named      map[string]Int  `json:"named" yaml:"named"`
+	Nested     struct{ N Int } `json:"nested" yaml:"nested"`
+	Tags       []string        `json:"tags" yaml:"tags"`
+	Skipped    Int             `json:"-" yaml:"-"`
+	Any        map[string]any  `json:"any" yaml:"any"`
+}
+
+// TestDecodeJSONShapes 测试DecodeJSON在各种字段形态下应用单次调用选项
+func TestDecodeJSONShapes(t *testing.T) {
+	data := []byte(`{
+		"id": "0x10", "note": "n", "name": "x", "COUNT": "0x1F", "ptr": "0b11",
+		"list": ["0x1", 2, "0o3"], "pair": ["1,5", 2], "named": {"a": "0xA"},
+		"nested": {"N": "0x2"}, "tags": ["t"], "Skipped": "0x1", "any": {"k": 1}
+	}`)
+	var got decodeTarget
+	if err := DecodeJSON(data, &got, WithIntSyntax(IntBasePrefix), WithNumberFormats(NumberFormatComma)); err != nil {
+		t.Fatalf("DecodeJSON failed: %v", err)
+	}
+	three := Int(3)
+	want := decodeTarget{
+		decodeBase: decodeBase{ID: 16, Note: "n"},
+		Name:       "x",
+		Count:      31,
+		Ptr:        &three,
+		List:       []Int{1, 2, 3},
+		Pair:       [2]Float{1.5, 2},
+		Named:      map[string]Int{"a": 10},
+		Nested:     struct{ N Int }{N: 2},
+		Tags:       []string{"t"},
+		Any:        map[string]any{"k": float64(1)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DecodeJSON result mismatch:\n got %+v\nwant %+v", got, want)
+	}
+
+	// null会清空指针与切片
+	if err := DecodeJSON([]byte(`{"ptr":null,"list":null}`), &got); err != nil {
+		t.Fatalf("DecodeJSON failed: %v", err)
+	}
+	if got.Ptr != nil || got.List != nil {
+		t.Errorf("expected nil ptr and list, got %v %v", got.Ptr, got.List)
+	}
+}
+
+// TestDecodeYAMLShapes 测试DecodeYAML在各种字段形态下应用单次调用选项
+func TestDecodeYAMLShapes(t *testing.T) {
+	data := []byte(`
+defaults: &defaults
+  count: "0x1F"
+id: "0x10"
+note: n
+name: x
+<<: *defaults
+ptr: "0b11"
+list: ["0x1", 2, "0o3"]
+pair: ["1,5", 2]
+named: {a: "0xA"}
+nested: {n: "0x2"}
+tags: [t]
+any: {k: 1}
+`)
+	var got decodeTarget
+	if err := DecodeYAML(data, &got, WithIntSyntax(IntBasePrefix), WithNumberFormats(NumberFormatComma)); err != nil {
+		t.Fatalf("DecodeYAML failed: %v", err)
+	}
+	three := Int(3)
+	want := decodeTarget{
+		decodeBase: decodeBase{ID: 16, Note: "n"},
+		Name:       "x",
+		Count:      31,
+		Ptr:        &three,
+		List:       []Int{1, 2, 3},
+		Pair:       [2]Float{1.5, 2},
+		Named:      map[string]Int{"a": 10},
+		Nested:     struct{ N Int }{N: 2},
+		Tags:       []string{"t"},
+		Any:        map[string]any{"k": 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DecodeYAML result mismatch:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+// TestDecodeErrors 测试DecodeJSON/DecodeYAML的错误处理与encoding/json一致
+func TestDecodeErrors(t *testing.T) {
+	var target decodeTarget
+	if err := DecodeJSON([]byte(`{"name":`), &target); err == nil {
+		t.Errorf("expected syntax error")
+	}
+	if err := DecodeJSON([]byte(`{}`), target); err == nil {
+		t.Errorf("expected error for non-pointer target")
+	}
+	if err := DecodeJSON([]byte(`{"name": 1}`), &target); err == nil {
+		t.Errorf("expected type error for non-strval field")
+	}
+	if err := DecodeYAML([]byte("name: [1"), &target); err == nil {
+		t.Errorf("expected YAML syntax error")
+	}
+
+	// strval字段的解析失败与UnmarshalJSON一致，只置零不返回错误
+	var jsonTarget, decodeTargetVal decodeTarget
+	data := []byte(`{"count":"bad","list":["1","x"]}`)
+	if err := json.Unmarshal(data, &jsonTarget); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if err := DecodeJSON(data, &decodeTargetVal); err != nil {
+		t.Fatalf("DecodeJSON failed: %v", err)
+	}
+	if !reflect.DeepEqual(jsonTarget, decodeTargetVal) {
+		t.Errorf("DecodeJSON differs from json.Unmarshal: %+v vs %+v", decodeTargetVal, jsonTarget)
+	}
+}
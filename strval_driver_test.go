@@ -0,0 +1,98 @@
+/*
+--------------------------------
+@Create 2026/10/19 02:40
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 02:40
+@Description 各数据库驱动返回值的Scan测试
+--------------------------------
+本文件模拟MySQL、Postgres与SQLite驱动返回的值（[]byte、各宽度整数、uint64、float32、time.Time等），
+测试各strval类型的Scan得到一致的结果。
+*/
+
+package strval
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// driverCase 驱动返回的一个值
+type driverCase struct {
+	column string // 驱动与列类型
+	value  interface{}
+	want   conformanceValues
+}
+
+var (
+	driverTime     = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	driverTimeFrac = time.Date(2024, 1, 2, 3, 4, 5, 500000000, time.UTC)
+)
+
+var driverCases = []driverCase{
+	// go-sql-driver/mysql：文本协议返回[]byte，二进制协议返回各宽度整数、无符号整数与float32
+	{"mysql VARCHAR", []byte("yes"), conformanceValues{B: true, S: "yes", N: NullBool{Bool: true, Valid: true}}},
+	{"mysql INT text protocol", []byte("42"), conformanceValues{I: 42, F: 42, S: "42"}},
+	{"mysql TINYINT(1) text protocol", []byte("1"), conformanceValues{B: true, I: 1, F: 1, S: "1", N: NullBool{Bool: true, Valid: true}}},
+	{"mysql BIT(1) set", []byte{1}, conformanceValues{B: true, I: 1, F: 1, S: "1", N: NullBool{Bool: true, Valid: true}}},
+	{"mysql BIT(1) clear", []byte{0}, conformanceValues{S: "0", N: NullBool{Valid: true}}},
+	{"mysql DECIMAL", []byte("12.50"), conformanceValues{F: 12.5, S: "12.50"}},
+	{"mysql MEDIUMINT", int32(-3), conformanceValues{B: true, I: -3, F: -3, S: "-3", N: NullBool{Bool: true, Valid: true}}},
+	{"mysql TINYINT", int8(1), conformanceValues{B: true, I: 1, F: 1, S: "1", N: NullBool{Bool: true, Valid: true}}},
+	{"mysql INT UNSIGNED", uint32(4000000000), conformanceValues{B: true, I: 4000000000, F: 4000000000, S: "4000000000", N: NullBool{Bool: true, Valid: true}}},
+	{"mysql BIGINT UNSIGNED", uint64(math.MaxUint64), conformanceValues{F: math.MaxUint64, S: "18446744073709551615"}},
+	{"mysql FLOAT", float32(0.1), conformanceValues{F: 0.1, S: "0.1"}},
+	{"mysql DATETIME parseTime", driverTime, conformanceValues{I: 1704164645, F: 1704164645, S: "2024-01-02T03:04:05Z"}},
+	{"mysql DATETIME", []byte("2024-01-02 03:04:05"), conformanceValues{S: "2024-01-02 03:04:05"}},
+
+	// lib/pq与pgx：布尔值与整数为原生类型，numeric返回[]byte，时间为time.Time
+	{"postgres boolean", false, conformanceValues{S: "false", N: NullBool{Valid: true}}},
+	{"postgres int4", int64(7), conformanceValues{B: true, I: 7, F: 7, S: "7", N: NullBool{Bool: true, Valid: true}}},
+	{"postgres numeric", []byte("3.25"), conformanceValues{F: 3.25, S: "3.25"}},
+	{"postgres float4", float32(2.5), conformanceValues{I: 2, F: 2.5, S: "2.5"}},
+	{"postgres timestamptz", driverTimeFrac, conformanceValues{I: 1704164645, F: 1704164645.5, S: "2024-01-02T03:04:05.5Z"}},
+	{"postgres NULL", nil, conformanceValues{}},
+
+	// mattn/go-sqlite3与modernc.org/sqlite：布尔值以整数存储，TEXT为string，BLOB为[]byte
+	{"sqlite INTEGER", int64(0), conformanceValues{S: "0", N: NullBool{Valid: true}}},
+	{"sqlite REAL", float64(2), conformanceValues{I: 2, F: 2, S: "2"}},
+	{"sqlite TEXT", "8080", conformanceValues{I: 8080, F: 8080, S: "8080"}},
+	{"sqlite BLOB", []byte("no"), conformanceValues{S: "no", N: NullBool{Valid: true}}},
+	{"sqlite DATETIME", driverTime, conformanceValues{I: 1704164645, F: 1704164645, S: "2024-01-02T03:04:05Z"}},
+
+	// 不支持的类型
+	{"unsupported", struct{}{}, conformanceValues{}},
+}
+
+// TestScanDrivers 测试各驱动返回值的Scan结果
+func TestScanDrivers(t *testing.T) {
+	withOptions(t, Options{})
+
+	for _, c := range driverCases {
+		got := conformanceSentinel
+		for _, v := range []interface{ Scan(interface{}) error }{&got.B, &got.I, &got.F, &got.S, &got.N} {
+			if err := v.Scan(c.value); err != nil {
+				t.Errorf("%s: %T.Scan(%#v) error: %v", c.column, v, c.value, err)
+			}
+		}
+		if got != c.want {
+			t.Errorf("%s: Scan(%#v) got %+v, want %+v", c.column, c.value, got, c.want)
+		}
+	}
+}
+
+// TestScanBytesCopied 测试String不引用驱动的缓冲区
+func TestScanBytesCopied(t *testing.T) {
+	withOptions(t, Options{})
+
+	buf := []byte("hello")
+	var s String
+	if err := s.Scan(buf); err != nil {
+		t.Fatalf("Scan error: %v", err)
+	}
+	copy(buf, "world")
+	if s != "hello" {
+		t.Errorf("String aliases the driver buffer: %q", s)
+	}
+}
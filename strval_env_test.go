@@ -0,0 +1,204 @@
+/*
+--------------------------------
+@Create 2026/10/18 20:10
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 20:10
+@Description 环境变量加载与.env文件解析测试
+--------------------------------
+本文件包含对LoadEnv、DecodeEnv以及.env文件解析的测试。
+*/
+
+package strval
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type envDB struct {
+	Host string `env:"HOST" default:"localhost"`
+	Port Int    `env:"PORT,required"`
+	TLS  *struct {
+		Enabled Bool
+	}
+}
+
+type envBase struct {
+	Name String `env:"NAME"`
+}
+
+type envConfig struct {
+	envBase
+	Debug    Bool          `env:"DEBUG"`
+	Ratio    Float         `yaml:"ratio" default:"0.5"`
+	MaxCount int           `default:"3"`
+	Timeout  time.Duration `env:"TIMEOUT"`
+	Started  time.Time     `env:"STARTED"`
+	Mode     NullBool      `env:"MODE"`
+	Secret   string        `env:"-"`
+	DB       envDB         `env:"DB"`
+}
+
+// TestDecodeEnv 测试变量名、前缀、嵌套结构体与默认值
+func TestDecodeEnv(t *testing.T) {
+	withOptions(t, Options{})
+
+	env := map[string]string{
+		"APP_NAME":           "demo",
+		"APP_DEBUG":          "yes",
+		"APP_RATIO":          "1.5",
+		"APP_TIMEOUT":        "1m",
+		"APP_STARTED":        "2026-10-18T20:10:00Z",
+		"APP_MODE":           "",
+		"APP_SECRET":         "ignored",
+		"APP_DB_PORT":        "5432",
+		"APP_DB_TLS_ENABLED": "1",
+	}
+	var cfg envConfig
+	if err := DecodeEnv(env, &cfg, "APP"); err != nil {
+		t.Fatalf("DecodeEnv error: %v", err)
+	}
+	if cfg.Name != "demo" || !bool(cfg.Debug) || cfg.Ratio != 1.5 || cfg.MaxCount != 3 ||
+		cfg.Timeout != time.Minute || cfg.Started.Year() != 2026 || cfg.Mode.Valid || cfg.Secret != "" ||
+		cfg.DB.Host != "localhost" || cfg.DB.Port != 5432 || !bool(cfg.DB.TLS.Enabled) {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+// TestDecodeEnvOptions 测试单次调用选项与strval标签
+func TestDecodeEnvOptions(t *testing.T) {
+	withOptions(t, Options{})
+
+	var cfg struct {
+		Port  Int  `env:"PORT"`
+		Ready Bool `env:"READY" strval:"true=ready"`
+	}
+	env := map[string]string{"PORT": "0x1F", "READY": "ready"}
+	if err := DecodeEnv(env, &cfg, "", WithIntSyntax(IntBasePrefix)); err != nil {
+		t.Fatalf("DecodeEnv error: %v", err)
+	}
+	if cfg.Port != 31 || !bool(cfg.Ready) {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+// TestDecodeEnvErrors 测试所有错误汇总为一个错误返回
+func TestDecodeEnvErrors(t *testing.T) {
+	withOptions(t, Options{})
+
+	env := map[string]string{
+		"APP_DEBUG":   "maybe",
+		"APP_RATIO":   "abc",
+		"APP_TIMEOUT": "soon",
+	}
+	var cfg envConfig
+	err := DecodeEnv(env, &cfg, "APP_")
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	for _, name := range []string{"APP_DEBUG", "APP_RATIO", "APP_TIMEOUT", "APP_DB_PORT"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("error should mention %s: %v", name, err)
+		}
+	}
+	var envErr *EnvError
+	if !errors.As(err, &envErr) || envErr.Name != "APP_DEBUG" || envErr.Value != "maybe" {
+		t.Errorf("errors.As = %+v", envErr)
+	}
+	if !errors.Is(err, errEnvRequired) {
+		t.Errorf("expected required error: %v", err)
+	}
+	if bool(cfg.Debug) || cfg.Ratio != 0 {
+		t.Errorf("invalid values should be zero: %+v", cfg)
+	}
+
+	if err := DecodeEnv(env, cfg, ""); err == nil {
+		t.Errorf("expected error for non-pointer value")
+	}
+}
+
+// TestReadDotEnv 测试.env格式解析
+func TestReadDotEnv(t *testing.T) {
+	content := `# comment
+APP_NAME=demo
+export APP_DEBUG = yes # inline comment
+APP_GREETING="hello\nworld # not a comment"
+APP_RAW='a\nb'
+APP_EMPTY=
+`
+	env, err := ReadDotEnv(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("ReadDotEnv error: %v", err)
+	}
+	want := map[string]string{
+		"APP_NAME":     "demo",
+		"APP_DEBUG":    "yes",
+		"APP_GREETING": "hello\nworld # not a comment",
+		"APP_RAW":      `a\nb`,
+		"APP_EMPTY":    "",
+	}
+	for k, v := range want {
+		if env[k] != v {
+			t.Errorf("%s = %q, want %q", k, env[k], v)
+		}
+	}
+	if len(env) != len(want) {
+		t.Errorf("unexpected variables: %v", env)
+	}
+
+	for _, bad := range []string{"APP_NAME\n", "1ABC=x\n", "A=\"unterminated\n", "A='x' y\n"} {
+		_, err := ReadDotEnv(strings.NewReader("# ok\n" + bad))
+		if err == nil || !strings.Contains(err.Error(), "line 2") {
+			t.Errorf("ReadDotEnv(%q) error = %v, want line 2", bad, err)
+		}
+	}
+}
+
+// TestLoadDotEnv 测试.env文件不覆盖已存在的环境变量，并由LoadEnv加载
+func TestLoadDotEnv(t *testing.T) {
+	withOptions(t, Options{})
+
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte("STRVAL_TEST_DB_PORT=5432\nSTRVAL_TEST_DEBUG=no\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("STRVAL_TEST_DEBUG", "yes")
+	// 由LoadDotEnv设置的变量在测试结束后清除
+	t.Setenv("STRVAL_TEST_DB_PORT", "")
+	os.Unsetenv("STRVAL_TEST_DB_PORT")
+
+	if err := LoadDotEnv(path); err != nil {
+		t.Fatalf("LoadDotEnv error: %v", err)
+	}
+	var cfg envConfig
+	if err := LoadEnv(&cfg, "STRVAL_TEST"); err != nil {
+		t.Fatalf("LoadEnv error: %v", err)
+	}
+	if !bool(cfg.Debug) || cfg.DB.Port != 5432 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+
+	if err := LoadDotEnv(filepath.Join(t.TempDir(), "missing.env")); err == nil {
+		t.Errorf("expected error for missing file")
+	}
+}
+
+// TestDecodeEnvRequiredSpace 测试选项前带空格的required标签
+func TestDecodeEnvRequiredSpace(t *testing.T) {
+	withOptions(t, Options{})
+
+	var cfg struct {
+		Port Int `env:"PORT, required"`
+	}
+	if err := DecodeEnv(map[string]string{}, &cfg, ""); !errors.Is(err, errEnvRequired) {
+		t.Errorf("expected required error, got %v", err)
+	}
+	if err := DecodeEnv(map[string]string{"PORT": "8080"}, &cfg, ""); err != nil || cfg.Port != 8080 {
+		t.Errorf("DecodeEnv = %+v, %v", cfg, err)
+	}
+}
@@ -0,0 +1,214 @@
+/*
+--------------------------------
+@Create 2026/10/18 19:30
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 19:30
+@Description flag.Value与BindFlags测试
+--------------------------------
+本文件包含对各类型flag.Value实现以及结构体参数绑定的测试。
+*/
+
+package strval
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	_ flag.Value = (*Bool)(nil)
+	_ flag.Value = (*Int)(nil)
+	_ flag.Value = (*Float)(nil)
+	_ flag.Value = (*String)(nil)
+	_ flag.Value = (*NullBool)(nil)
+	_ flag.Value = (*Preserved[Int])(nil)
+)
+
+// TestFlagValue 测试Set使用宽松解析规则，解析失败时返回错误
+func TestFlagValue(t *testing.T) {
+	withOptions(t, Options{})
+
+	var b Bool
+	if err := b.Set("yes"); err != nil || !bool(b) || b.String() != "true" {
+		t.Errorf("Bool.Set(yes) = %v, %v", b, err)
+	}
+	if err := b.Set("maybe"); err == nil {
+		t.Errorf("Bool.Set(maybe) expected error")
+	}
+
+	var i Int
+	if err := i.Set(" 42 "); err != nil || i != 42 || i.String() != "42" {
+		t.Errorf("Int.Set(42) = %v, %v", i, err)
+	}
+	if err := i.Set("abc"); err == nil {
+		t.Errorf("Int.Set(abc) expected error")
+	}
+
+	var f Float
+	if err := f.Set("1.5"); err != nil || f != 1.5 || f.String() != "1.5" {
+		t.Errorf("Float.Set(1.5) = %v, %v", f, err)
+	}
+
+	var n NullBool
+	if n.String() != "" {
+		t.Errorf("invalid NullBool.String() = %q", n.String())
+	}
+	if err := n.Set("no"); err != nil || !n.Valid || n.Bool || n.String() != "false" {
+		t.Errorf("NullBool.Set(no) = %+v, %v", n, err)
+	}
+
+	var p Preserved[Int]
+	if err := p.Set("7"); err != nil || p.Val != 7 || p.String() != "7" || p.Type() != "int" {
+		t.Errorf("Preserved.Set(7) = %+v, %v", p, err)
+	}
+}
+
+type flagDB struct {
+	Host string   `yaml:"host" default:"localhost" usage:"数据库地址"`
+	Port Int      `yaml:"port" default:"5432"`
+	TLS  *flagTLS `yaml:"tls"`
+	Mode NullBool `yaml:"mode"`
+	Skip chan int `flag:"-"`
+	Rate Preserved[Float]
+}
+
+type flagTLS struct {
+	Enabled Bool `yaml:"enabled"`
+}
+
+type flagConfig struct {
+	Enabled  Bool          `yaml:"enabled" usage:"是否启用"`
+	Port     Int           `flag:"listen" json:"port" usage:"监听端口" default:"8080"`
+	Name     String        `json:"name"`
+	Ratio    Float         `default:"0.5"`
+	MaxCount int           `default:"3"`
+	Timeout  time.Duration `default:"2s"`
+	Verbose  bool
+	Tags     []string `flag:"-"`
+	DB       flagDB   `yaml:"db"`
+}
+
+// TestBindFlags 测试参数名、默认值与宽松解析
+func TestBindFlags(t *testing.T) {
+	withOptions(t, Options{})
+
+	var cfg flagConfig
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	if err := BindFlags(fs, &cfg); err != nil {
+		t.Fatalf("BindFlags error: %v", err)
+	}
+
+	for _, name := range []string{"enabled", "listen", "name", "ratio", "max-count", "timeout", "verbose",
+		"db.host", "db.port", "db.tls.enabled", "db.mode", "db.rate"} {
+		if fs.Lookup(name) == nil {
+			t.Errorf("flag %q not registered", name)
+		}
+	}
+	if fs.Lookup("tags") != nil || fs.Lookup("db.skip") != nil {
+		t.Errorf("flag:\"-\" fields should be skipped")
+	}
+	if cfg.Port != 8080 || cfg.Ratio != 0.5 || cfg.MaxCount != 3 || cfg.Timeout != 2*time.Second ||
+		cfg.DB.Host != "localhost" || cfg.DB.Port != 5432 {
+		t.Errorf("defaults not applied: %+v", cfg)
+	}
+
+	err := fs.Parse([]string{"--enabled=yes", "--listen", "9_090", "--name", "app", "--verbose",
+		"--db.tls.enabled", "--db.port=0x10", "--db.mode=no", "--db.rate=2.5", "rest"})
+	if err == nil {
+		t.Fatalf("expected error for 0x10 without IntBasePrefix")
+	}
+
+	cfg = flagConfig{}
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	if err := BindFlags(fs, &cfg, WithIntSyntax(IntBasePrefix|IntUnderscore)); err != nil {
+		t.Fatalf("BindFlags error: %v", err)
+	}
+	err = fs.Parse([]string{"--enabled=yes", "--listen", "9_090", "--name", "app", "--verbose",
+		"--db.tls.enabled", "--db.port=0x10", "--db.mode=no", "--db.rate=2.5", "rest"})
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if !bool(cfg.Enabled) || cfg.Port != 9090 || cfg.Name != "app" || !cfg.Verbose ||
+		!bool(cfg.DB.TLS.Enabled) || cfg.DB.Port != 16 || !cfg.DB.Mode.Valid || cfg.DB.Mode.Bool ||
+		cfg.DB.Rate.Val != 2.5 {
+		t.Errorf("unexpected config after parse: %+v", cfg)
+	}
+	if args := fs.Args(); len(args) != 1 || args[0] != "rest" {
+		t.Errorf("unexpected args %v", args)
+	}
+}
+
+// TestBindFlagsPreserved 测试Preserved字段同样使用单次调用选项解析，包装Bool时允许省略参数值
+func TestBindFlagsPreserved(t *testing.T) {
+	withOptions(t, Options{})
+
+	var cfg struct {
+		Port  Preserved[Int]  `default:"0x1F"`
+		Debug Preserved[Bool] `flag:"debug"`
+	}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	if err := BindFlags(fs, &cfg, WithIntSyntax(IntBasePrefix)); err != nil {
+		t.Fatalf("BindFlags error: %v", err)
+	}
+	if cfg.Port.Val != 31 {
+		t.Errorf("default not applied: %+v", cfg.Port)
+	}
+	if err := fs.Parse([]string{"--port=0x10", "--debug", "rest"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if cfg.Port.Val != 16 || !bool(cfg.Debug.Val) || !cfg.Port.Modified() {
+		t.Errorf("unexpected config after parse: %+v", cfg)
+	}
+	// 包装Int时不是布尔参数，参数值不能省略
+	if err := fs.Parse([]string{"--port"}); err == nil {
+		t.Errorf("expected error for --port without value")
+	}
+}
+
+// TestBindFlagsUsage 测试usage标签与默认值在帮助信息中的输出
+func TestBindFlagsUsage(t *testing.T) {
+	withOptions(t, Options{})
+
+	var cfg flagConfig
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	if err := BindFlags(fs, &cfg); err != nil {
+		t.Fatalf("BindFlags error: %v", err)
+	}
+	fs.PrintDefaults()
+	out := buf.String()
+	for _, want := range []string{"-listen value\n    \t监听端口 (default 8080)", "-enabled\n    \t是否启用", "数据库地址 (default \"localhost\")", "-db.mode\n    \t\n", "-enabled\n    \t是否启用\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("usage output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+// TestBindFlagsError 测试参数错误与不支持的字段类型
+func TestBindFlagsError(t *testing.T) {
+	withOptions(t, Options{})
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	if err := BindFlags(fs, flagConfig{}); err == nil {
+		t.Errorf("expected error for non-pointer value")
+	}
+	if err := BindFlags(fs, &struct {
+		Tags []string
+	}{}); err == nil || !strings.Contains(err.Error(), "Tags") {
+		t.Errorf("expected unsupported type error, got %v", err)
+	}
+	if err := BindFlags(fs, &struct {
+		Port Int `default:"abc"`
+	}{}); err == nil {
+		t.Errorf("expected invalid default error")
+	}
+}
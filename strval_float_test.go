@@ -0,0 +1,173 @@
+//go:build !strval_noyaml
+
+/*
+--------------------------------
+@Create 2026/10/18 09:30
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 09:30
+@Description Float非有限值策略测试
+--------------------------------
+本文件包含对Float类型NaN/±Inf处理策略的测试，覆盖JSON、YAML与数据库路径的输入与输出。
+*/
+
+package strval
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestFloatNonFiniteOutput 测试Float序列化NaN/±Inf时的各输出策略
+func TestFloatNonFiniteOutput(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		withOptions(t, Options{})
+		if _, err := json.Marshal(Float(math.NaN())); err == nil {
+			t.Errorf("expected JSON marshal error for NaN")
+		}
+		data, err := yaml.Marshal(Float(math.Inf(1)))
+		if err != nil || string(data) != ".inf\n" {
+			t.Errorf("expected .inf, got %q, err %v", data, err)
+		}
+		val, err := Float(math.NaN()).Value()
+		if err != nil || !math.IsNaN(val.(float64)) {
+			t.Errorf("expected NaN database value, got %v, err %v", val, err)
+		}
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		withOptions(t, Options{NonFiniteOutput: NonFiniteOutputNull})
+		data, err := json.Marshal([]Float{1.5, Float(math.NaN())})
+		if err != nil || string(data) != "[1.5,null]" {
+			t.Errorf("expected [1.5,null], got %s, err %v", data, err)
+		}
+		ydata, err := yaml.Marshal(Float(math.Inf(-1)))
+		if err != nil || string(ydata) != "null\n" {
+			t.Errorf("expected null, got %q, err %v", ydata, err)
+		}
+		val, err := Float(math.Inf(1)).Value()
+		if err != nil || val != nil {
+			t.Errorf("expected nil database value, got %v, err %v", val, err)
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		withOptions(t, Options{NonFiniteOutput: NonFiniteOutputString})
+		data, err := json.Marshal([]Float{Float(math.NaN()), Float(math.Inf(1)), Float(math.Inf(-1))})
+		if err != nil || string(data) != `["NaN","Infinity","-Infinity"]` {
+			t.Errorf("unexpected output %s, err %v", data, err)
+		}
+		ydata, err := yaml.Marshal(Float(math.NaN()))
+		if err != nil || string(ydata) != "NaN\n" {
+			t.Errorf("expected NaN, got %q, err %v", ydata, err)
+		}
+		val, err := Float(math.Inf(-1)).Value()
+		if err != nil || val != "-Infinity" {
+			t.Errorf("expected -Infinity database value, got %v, err %v", val, err)
+		}
+
+		// 字符串形式可以原样解析回来
+		var fs []Float
+		if err := json.Unmarshal(data, &fs); err != nil {
+			t.Fatalf("unmarshal failed: %v", err)
+		}
+		if !math.IsNaN(float64(fs[0])) || !math.IsInf(float64(fs[1]), 1) || !math.IsInf(float64(fs[2]), -1) {
+			t.Errorf("round trip failed: %v", fs)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		withOptions(t, Options{NonFiniteOutput: NonFiniteOutputError})
+		if _, err := json.Marshal(Float(math.NaN())); err == nil {
+			t.Errorf("expected JSON marshal error")
+		}
+		if _, err := yaml.Marshal(Float(math.NaN())); err == nil {
+			t.Errorf("expected YAML marshal error")
+		}
+		if _, err := Float(math.Inf(1)).Value(); err == nil {
+			t.Errorf("expected database value error")
+		}
+		// 有限值不受影响
+		if data, err := json.Marshal(Float(2.5)); err != nil || string(data) != "2.5" {
+			t.Errorf("expected 2.5, got %s, err %v", data, err)
+		}
+	})
+}
+
+// TestFloatNonFiniteInput 测试Float反序列化NaN/±Inf时的接受与拒绝策略
+func TestFloatNonFiniteInput(t *testing.T) {
+	jsonInputs := []string{`"NaN"`, `"Infinity"`, `"-Infinity"`, `"inf"`, `".nan"`, `"-.inf"`}
+	yamlInputs := []string{".nan", ".inf", "-.inf", ".NaN", `".inf"`, `"Infinity"`}
+
+	t.Run("Accept", func(t *testing.T) {
+		withOptions(t, Options{})
+		for _, in := range jsonInputs {
+			var f Float
+			if err := json.Unmarshal([]byte(in), &f); err != nil || !isNonFinite(float64(f)) {
+				t.Errorf("JSON %s: expected non-finite value, got %v, err %v", in, f, err)
+			}
+		}
+		for _, in := range yamlInputs {
+			var f Float
+			if err := yaml.Unmarshal([]byte(in), &f); err != nil || !isNonFinite(float64(f)) {
+				t.Errorf("YAML %s: expected non-finite value, got %v, err %v", in, f, err)
+			}
+		}
+		var f Float
+		if err := f.Scan(math.NaN()); err != nil || !math.IsNaN(float64(f)) {
+			t.Errorf("Scan(NaN): expected NaN, got %v, err %v", f, err)
+		}
+		if err := f.Scan("-Infinity"); err != nil || !math.IsInf(float64(f), -1) {
+			t.Errorf("Scan(\"-Infinity\"): expected -Inf, got %v, err %v", f, err)
+		}
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		withOptions(t, Options{NonFiniteInput: NonFiniteInputReject})
+		for _, in := range jsonInputs {
+			f := Float(1)
+			if err := json.Unmarshal([]byte(in), &f); err != nil || f != 0 {
+				t.Errorf("JSON %s: expected 0, got %v, err %v", in, f, err)
+			}
+		}
+		for _, in := range yamlInputs {
+			f := Float(1)
+			if err := yaml.Unmarshal([]byte(in), &f); err != nil || f != 0 {
+				t.Errorf("YAML %s: expected 0, got %v, err %v", in, f, err)
+			}
+		}
+		f := Float(1)
+		if err := f.Scan(math.Inf(1)); err != nil || f != 0 {
+			t.Errorf("Scan(+Inf): expected 0, got %v, err %v", f, err)
+		}
+		f = Float(1)
+		if err := f.Scan("NaN"); err != nil || f != 0 {
+			t.Errorf("Scan(\"NaN\"): expected 0, got %v, err %v", f, err)
+		}
+		// 有限值不受影响
+		if err := json.Unmarshal([]byte(`"1.25"`), &f); err != nil || f != 1.25 {
+			t.Errorf("expected 1.25, got %v, err %v", f, err)
+		}
+	})
+}
+
+// TestFloatSurroundingWhitespace 测试Float与Int一致忽略首尾空白
+func TestFloatSurroundingWhitespace(t *testing.T) {
+	withOptions(t, Options{})
+
+	var f Float
+	if err := json.Unmarshal([]byte(`" 12.5 "`), &f); err != nil || f != 12.5 {
+		t.Errorf("UnmarshalJSON = %v, %v, want 12.5", f, err)
+	}
+	f = 0
+	if err := json.Unmarshal([]byte(`"\t-0.25\n"`), &f); err != nil || f != -0.25 {
+		t.Errorf("UnmarshalJSON = %v, %v, want -0.25", f, err)
+	}
+	f = 0
+	if err := yaml.Unmarshal([]byte(`" .inf "`), &f); err != nil || !math.IsInf(float64(f), 1) {
+		t.Errorf("UnmarshalYAML = %v, %v, want +Inf", f, err)
+	}
+}
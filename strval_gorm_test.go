@@ -0,0 +1,78 @@
+/*
+--------------------------------
+@Create 2026/10/19 03:40
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 03:40
+@Description GORM列类型接口的测试
+--------------------------------
+本文件测试各strval类型GormDataType返回的数据类型。
+*/
+
+package strval
+
+import (
+	"testing"
+)
+
+// gormDataTyper GORM的GormDataTypeInterface接口
+type gormDataTyper interface {
+	GormDataType() string
+}
+
+// longColumn 测试用的长度不同的字符对
+type longColumn struct{}
+
+func (longColumn) BoolColumn() BoolColumn {
+	return BoolColumn{Kind: BoolColumnChars, True: "yes", False: "no"}
+}
+
+// cjkColumn 测试用的多字节字符对，长度按字符数计算
+type cjkColumn struct{}
+
+func (cjkColumn) BoolColumn() BoolColumn {
+	return BoolColumn{Kind: BoolColumnChars, True: "启用", False: "停用"}
+}
+
+// TestGormDataType 测试各类型返回的GORM数据类型
+func TestGormDataType(t *testing.T) {
+	withOptions(t, Options{})
+
+	tests := []struct {
+		value gormDataTyper
+		want  string
+	}{
+		{Bool(false), "bool"},
+		{NullBool{}, "bool"},
+		{Int(0), "int"},
+		{Float(0), "float"},
+		{String(""), "string"},
+		{ColumnBool[ColumnYN]{}, "char(1)"},
+		{ColumnBool[ColumnOneZero]{}, "smallint"},
+		{ColumnNullBool[ColumnTF]{}, "char(1)"},
+		{ColumnBool[longColumn]{}, "varchar(3)"},
+		{ColumnBool[cjkColumn]{}, "char(2)"},
+		{Preserved[Int]{}, "int"},
+		{Preserved[NullBool]{}, "bool"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.value.GormDataType(); got != tt.want {
+			t.Errorf("%T.GormDataType() = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+// TestGormDataTypeBoolColumn 测试Bool/NullBool的列类型与全局选项BoolColumn一致
+func TestGormDataTypeBoolColumn(t *testing.T) {
+	withOptions(t, Options{BoolColumn: BoolColumnYN})
+
+	for _, v := range []gormDataTyper{Bool(false), NullBool{}, Preserved[Bool]{}} {
+		if got := v.GormDataType(); got != "char(1)" {
+			t.Errorf("%T.GormDataType() = %q, want \"char(1)\"", v, got)
+		}
+	}
+	if got := (ColumnBool[ColumnOneZero]{}).GormDataType(); got != "smallint" {
+		t.Errorf("ColumnBool[ColumnOneZero].GormDataType() = %q, want \"smallint\"", got)
+	}
+}
@@ -0,0 +1,139 @@
+//go:build strval_gorm
+
+/*
+--------------------------------
+@Create 2026/10/19 04:10
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 04:10
+@Description 按方言选择GORM列类型的测试
+--------------------------------
+本文件测试各strval类型GormDBDataType按方言与字段标签返回的列类型，只在使用构建标签strval_gorm时运行。
+*/
+
+package strval
+
+import (
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// gormDBDataTyper GORM的Migrator所使用的GormDataTypeInterface接口
+type gormDBDataTyper interface {
+	GormDBDataType(*gorm.DB, *schema.Field) string
+}
+
+// testDialector 只提供方言名称的测试用方言
+type testDialector struct {
+	gorm.Dialector
+	name string
+}
+
+func (d testDialector) Name() string { return d.name }
+
+// testDB 返回使用指定方言的数据库连接
+func testDB(dialect string) *gorm.DB {
+	return &gorm.DB{Config: &gorm.Config{Dialector: testDialector{name: dialect}}}
+}
+
+// chineseColumn 测试用的多字节字符对
+type chineseColumn struct{}
+
+func (chineseColumn) BoolColumn() BoolColumn {
+	return BoolColumn{Kind: BoolColumnChars, True: "是", False: "否"}
+}
+
+// TestGormDBDataType 测试各类型按方言与字段标签返回的列类型
+func TestGormDBDataType(t *testing.T) {
+	withOptions(t, Options{})
+
+	tests := []struct {
+		value   gormDBDataTyper
+		dialect string
+		field   schema.Field
+		want    string
+	}{
+		{Bool(false), "mysql", schema.Field{}, ""},
+		{NullBool{}, "postgres", schema.Field{}, ""},
+		{Int(0), "mysql", schema.Field{}, "bigint"},
+		{Int(0), "mysql", schema.Field{Size: 8}, "tinyint"},
+		{Int(0), "mysql", schema.Field{Size: 24}, "mediumint"},
+		{Int(0), "mysql", schema.Field{Size: 32}, "int"},
+		{Int(0), "postgres", schema.Field{Size: 8}, "smallint"},
+		{Int(0), "postgres", schema.Field{Size: 32}, "integer"},
+		{Int(0), "sqlserver", schema.Field{Size: 8}, "smallint"},
+		{Int(0), "sqlite", schema.Field{Size: 16}, "integer"},
+		{Int(0), "oracle", schema.Field{}, ""},
+		{Float(0), "mysql", schema.Field{}, "double"},
+		{Float(0), "mysql", schema.Field{Size: 32}, "float"},
+		{Float(0), "mysql", schema.Field{Precision: 10, Scale: 2}, "decimal(10,2)"},
+		{Float(0), "postgres", schema.Field{}, "double precision"},
+		{Float(0), "postgres", schema.Field{Precision: 10, Scale: 2}, "numeric(10,2)"},
+		{Float(0), "sqlserver", schema.Field{Size: 32}, "real"},
+		{Float(0), "sqlite", schema.Field{}, "real"},
+		{String(""), "mysql", schema.Field{}, ""},
+		{String(""), "mysql", schema.Field{Size: 32}, "varchar(32)"},
+		{String(""), "mysql", schema.Field{Size: 70000}, "mediumtext"},
+		{String(""), "postgres", schema.Field{}, "text"},
+		{String(""), "postgres", schema.Field{Size: 32}, "varchar(32)"},
+		{String(""), "sqlserver", schema.Field{Size: 32}, "nvarchar(32)"},
+		{String(""), "sqlserver", schema.Field{}, "nvarchar(MAX)"},
+		{String(""), "sqlite", schema.Field{Size: 32}, "text"},
+		{ColumnBool[ColumnYN]{}, "mysql", schema.Field{}, "char(1)"},
+		{ColumnBool[ColumnYN]{}, "sqlserver", schema.Field{}, "nchar(1)"},
+		{ColumnBool[ColumnOneZero]{}, "mysql", schema.Field{}, "tinyint(1)"},
+		{ColumnBool[ColumnOneZero]{}, "postgres", schema.Field{}, "smallint"},
+		{ColumnBool[ColumnOneZero]{}, "oracle", schema.Field{}, "number(1)"},
+		{ColumnNullBool[ColumnTF]{}, "postgres", schema.Field{}, "char(1)"},
+		{ColumnBool[longColumn]{}, "mysql", schema.Field{}, "varchar(3)"},
+		{ColumnBool[longColumn]{}, "sqlserver", schema.Field{}, "nvarchar(3)"},
+		{ColumnBool[chineseColumn]{}, "mysql", schema.Field{}, "char(1)"},
+		{Preserved[Int]{}, "postgres", schema.Field{Size: 16}, "smallint"},
+		{Preserved[String]{}, "mysql", schema.Field{Size: 64}, "varchar(64)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.value.GormDBDataType(testDB(tt.dialect), &tt.field); got != tt.want {
+			t.Errorf("%T.GormDBDataType(%s, size %d precision %d) = %q, want %q",
+				tt.value, tt.dialect, tt.field.Size, tt.field.Precision, got, tt.want)
+		}
+	}
+}
+
+// TestGormDBDataTypeBoolColumn 测试Bool/NullBool按全局选项BoolColumn与方言返回列类型
+func TestGormDBDataTypeBoolColumn(t *testing.T) {
+	withOptions(t, Options{BoolColumn: BoolColumnOneZero})
+
+	for _, v := range []gormDBDataTyper{Bool(false), NullBool{}, Preserved[Bool]{}} {
+		if got := v.GormDBDataType(testDB("mysql"), &schema.Field{}); got != "tinyint(1)" {
+			t.Errorf("%T.GormDBDataType(mysql) = %q, want \"tinyint(1)\"", v, got)
+		}
+	}
+	// 未初始化的连接交给GormDataType返回的通用类型
+	if got := Bool(false).GormDBDataType(&gorm.DB{}, &schema.Field{}); got != "" {
+		t.Errorf("Bool.GormDBDataType(uninitialized) = %q, want \"\"", got)
+	}
+}
+
+// TestGormDBDataTypeNilField 测试字段信息为nil时按未设置标签处理
+func TestGormDBDataTypeNilField(t *testing.T) {
+	withOptions(t, Options{})
+
+	tests := []struct {
+		value gormDBDataTyper
+		want  string
+	}{
+		{Int(0), "bigint"},
+		{Float(0), "double precision"},
+		{String(""), "text"},
+		{Preserved[Int]{}, "bigint"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.value.GormDBDataType(testDB("postgres"), nil); got != tt.want {
+			t.Errorf("%T.GormDBDataType(postgres, nil) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+/*
+--------------------------------
+@Create 2026/10/18 20:50
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 20:50
+@Description HTTP请求参数绑定与RFC 7807错误响应测试
+--------------------------------
+本文件包含对BindRequest各参数来源以及WriteProblem的测试。
+*/
+
+package strval
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type httpPage struct {
+	Limit  Int `query:"limit,required"`
+	Offset Int `query:"offset" default:"0"`
+}
+
+type httpRequest struct {
+	httpPage
+	ID     Int                   `path:"id"`
+	Active Bool                  `query:"active" default:"true"`
+	Tags   []String              `query:"tag"`
+	Name   String                `query:"name" form:"name"`
+	Score  Float                 `form:"score"`
+	Avatar *multipart.FileHeader `form:"avatar"`
+	Token  string                `header:"X-Token"`
+	Lang   string                `cookie:"lang"`
+	Mode   NullBool              `query:"mode"`
+	Ignore string
+}
+
+// serve 通过ServeMux处理请求，使r.PathValue可用
+func serve(t *testing.T, r *http.Request, handler func(w http.ResponseWriter, r *http.Request)) *httptest.ResponseRecorder {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/items/{id}", handler)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	return w
+}
+
+// TestBindRequest 测试查询参数、路径参数、请求头与Cookie的绑定
+func TestBindRequest(t *testing.T) {
+	withOptions(t, Options{})
+
+	r := httptest.NewRequest(http.MethodGet, "/items/42?active=no&limit=20&tag=a&tag=b&name=demo&mode=yes", nil)
+	r.Header.Set("X-Token", "secret")
+	r.AddCookie(&http.Cookie{Name: "lang", Value: "zh"})
+
+	var req httpRequest
+	serve(t, r, func(w http.ResponseWriter, r *http.Request) {
+		if err := BindRequest(r, &req); err != nil {
+			t.Fatalf("BindRequest error: %v", err)
+		}
+	})
+	if req.ID != 42 || bool(req.Active) || req.Limit != 20 || req.Offset != 0 || req.Name != "demo" ||
+		len(req.Tags) != 2 || req.Tags[1] != "b" || req.Token != "secret" || req.Lang != "zh" ||
+		!req.Mode.Valid || !req.Mode.Bool {
+		t.Errorf("unexpected request: %+v", req)
+	}
+}
+
+// TestBindRequestForm 测试urlencoded与multipart表单
+func TestBindRequestForm(t *testing.T) {
+	withOptions(t, Options{})
+
+	t.Run("URLEncoded", func(t *testing.T) {
+		body := url.Values{"name": {"form"}, "score": {"9.5"}}.Encode()
+		r := httptest.NewRequest(http.MethodPost, "/items/1?limit=1", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		var req httpRequest
+		if err := BindRequest(r, &req); err != nil {
+			t.Fatalf("BindRequest error: %v", err)
+		}
+		// 查询参数中没有name时使用表单中的值
+		if req.Name != "form" || req.Score != 9.5 || !bool(req.Active) {
+			t.Errorf("unexpected request: %+v", req)
+		}
+	})
+
+	t.Run("Multipart", func(t *testing.T) {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		mw.WriteField("score", "7")
+		fw, _ := mw.CreateFormFile("avatar", "avatar.png")
+		fw.Write([]byte("png"))
+		mw.Close()
+
+		r := httptest.NewRequest(http.MethodPost, "/items/1?limit=1", &body)
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+		var req httpRequest
+		if err := BindRequest(r, &req); err != nil {
+			t.Fatalf("BindRequest error: %v", err)
+		}
+		if req.Score != 7 || req.Avatar == nil || req.Avatar.Filename != "avatar.png" {
+			t.Fatalf("unexpected request: %+v", req)
+		}
+		f, err := req.Avatar.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if data, _ := io.ReadAll(f); string(data) != "png" {
+			t.Errorf("unexpected file content %q", data)
+		}
+	})
+}
+
+// TestBindRequestErrors 测试错误汇总与problem+json响应
+func TestBindRequestErrors(t *testing.T) {
+	withOptions(t, Options{})
+
+	r := httptest.NewRequest(http.MethodGet, "/items/abc?active=maybe", nil)
+	w := serve(t, r, func(w http.ResponseWriter, r *http.Request) {
+		var req httpRequest
+		err := BindRequest(r, &req)
+		if err == nil {
+			t.Fatalf("expected error")
+		}
+		var be *BindError
+		if !errors.As(err, &be) || be.In != "query" || be.Name != "limit" || !errors.Is(err, errBindRequired) {
+			t.Errorf("errors.As = %+v", be)
+		}
+		WriteProblem(w, http.StatusBadRequest, err)
+	})
+
+	if w.Code != http.StatusBadRequest || w.Header().Get("Content-Type") != "application/problem+json" {
+		t.Fatalf("unexpected response %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	var p Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatalf("invalid problem JSON: %v", err)
+	}
+	if p.Type != "about:blank" || p.Title != "Bad Request" || p.Status != 400 {
+		t.Errorf("unexpected problem: %+v", p)
+	}
+	got := map[string]string{}
+	for _, param := range p.InvalidParams {
+		got[param.In+":"+param.Name] = param.Reason
+	}
+	for _, key := range []string{"query:limit", "path:id", "query:active"} {
+		if got[key] == "" {
+			t.Errorf("missing invalid param %s in %+v", key, p.InvalidParams)
+		}
+	}
+	if len(got) != 3 {
+		t.Errorf("unexpected invalid params %+v", p.InvalidParams)
+	}
+}
+
+// TestNewProblem 测试非BindError错误写入detail
+func TestNewProblem(t *testing.T) {
+	p := NewProblem(http.StatusInternalServerError, errors.New("database unavailable"))
+	if p.Detail != "database unavailable" || len(p.InvalidParams) != 0 || p.Title != "Internal Server Error" {
+		t.Errorf("unexpected problem: %+v", p)
+	}
+
+	if err := BindRequest(httptest.NewRequest(http.MethodGet, "/", nil), httpRequest{}); err == nil {
+		t.Errorf("expected error for non-pointer value")
+	}
+}
+
+// TestBindRequestRequiredSpace 测试选项前带空格的required标签
+func TestBindRequestRequiredSpace(t *testing.T) {
+	withOptions(t, Options{})
+
+	var req struct {
+		Limit Int `query:"limit, required"`
+	}
+	r := httptest.NewRequest(http.MethodGet, "/items", nil)
+	if err := BindRequest(r, &req); !errors.Is(err, errBindRequired) {
+		t.Errorf("expected required error, got %v", err)
+	}
+	r = httptest.NewRequest(http.MethodGet, "/items?limit=5", nil)
+	if err := BindRequest(r, &req); err != nil || req.Limit != 5 {
+		t.Errorf("BindRequest = %+v, %v", req, err)
+	}
+}
@@ -0,0 +1,144 @@
+/*
+--------------------------------
+@Create 2026/10/18 23:30
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 23:30
+@Description INI与.properties解析测试
+--------------------------------
+本文件包含对INI分节、.properties点分键名映射到嵌套结构体以及带行号的错误报告的测试。
+*/
+
+package strval
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type iniPool struct {
+	Size    Int           `ini:"size" properties:"size"`
+	Timeout time.Duration `ini:"timeout" properties:"timeout"`
+}
+
+type iniConfig struct {
+	Name    String `ini:"name" properties:"app.name"`
+	Enabled Bool   `ini:"enabled" properties:"enabled"`
+	Server  struct {
+		Host    String   `ini:"host"`
+		Port    Int      `ini:"port"`
+		Verbose NullBool `ini:"verbose"`
+	} `ini:"server"`
+	Pool   *iniPool          `ini:"pool" properties:"pool"`
+	Ratio  Float             `ini:"ratio" strval:"decimals=2"`
+	Labels map[string]String `ini:"labels" properties:"labels"`
+}
+
+// TestDecodeINI 测试分节、点分节名与宽松的值解析
+func TestDecodeINI(t *testing.T) {
+	withOptions(t, Options{})
+
+	input := `; legacy service
+name = "billing"
+enabled = on
+
+[server]
+host=db.local
+PORT: 8080
+verbose =
+
+[pool]
+size = 10
+timeout = 5s
+
+[labels]
+team = core
+env.region = eu
+
+[server.extra]
+ignored = 1
+`
+	var c iniConfig
+	err := DecodeINI(strings.NewReader(input), &c, WithBoolVocabulary(ExtendedBoolVocabulary))
+	if err != nil {
+		t.Fatalf("DecodeINI error: %v", err)
+	}
+	if c.Name != "billing" || !bool(c.Enabled) || c.Server.Host != "db.local" || c.Server.Port != 8080 || c.Server.Verbose.Valid {
+		t.Errorf("unexpected result: %+v", c)
+	}
+	if c.Pool == nil || c.Pool.Size != 10 || c.Pool.Timeout != 5*time.Second {
+		t.Errorf("unexpected pool: %+v", c.Pool)
+	}
+	if c.Labels["team"] != "core" || c.Labels["env.region"] != "eu" {
+		t.Errorf("unexpected labels: %v", c.Labels)
+	}
+}
+
+// TestDecodeProperties 测试点分键名、续行与转义
+func TestDecodeProperties(t *testing.T) {
+	withOptions(t, Options{})
+
+	input := `# legacy service
+! another comment
+app.name = bill\
+           ing
+enabled: yes
+pool.size 10
+pool.timeout=1m
+labels.owner=\u5f20\u4e09
+labels.path=C:\\data\=x
+`
+	var c iniConfig
+	if err := DecodeProperties(strings.NewReader(input), &c); err != nil {
+		t.Fatalf("DecodeProperties error: %v", err)
+	}
+	if c.Name != "billing" || !bool(c.Enabled) || c.Pool == nil || c.Pool.Size != 10 || c.Pool.Timeout != time.Minute {
+		t.Errorf("unexpected result: %+v %+v", c, c.Pool)
+	}
+	if c.Labels["owner"] != "张三" || c.Labels["path"] != `C:\data=x` {
+		t.Errorf("unexpected labels: %v", c.Labels)
+	}
+}
+
+// TestConfigErrors 测试错误包含文件名与行号
+func TestConfigErrors(t *testing.T) {
+	withOptions(t, Options{})
+
+	var c iniConfig
+	err := DecodeINI(strings.NewReader("enabled = maybe\n\n[pool]\nsize = ten\n"), &c)
+	var lineErr *LineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 1 || lineErr.Key != "enabled" || lineErr.Value != "maybe" {
+		t.Fatalf("expected LineError for enabled, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "line 4: pool.size") {
+		t.Errorf("error should report pool.size on line 4: %v", err)
+	}
+
+	for _, input := range []string{"[server\n", "[]\n", "no separator\n", "= value\n"} {
+		if err := DecodeINI(strings.NewReader(input), &c); !errors.As(err, &lineErr) || lineErr.Line != 1 {
+			t.Errorf("DecodeINI(%q) error = %v", input, err)
+		}
+	}
+	if err := DecodeProperties(strings.NewReader("# c\nlabels.x=\\u12\n"), &c); !errors.As(err, &lineErr) || lineErr.Line != 2 {
+		t.Errorf("expected malformed escape on line 2, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "app.properties")
+	if err := os.WriteFile(path, []byte("app.name=ok\n\npool.size=\\\n  many\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	err = DecodePropertiesFile(path, &c)
+	if err == nil || !strings.Contains(err.Error(), path+":3: pool.size") {
+		t.Errorf("error should report %s:3, got %v", path, err)
+	}
+	if err := DecodeINIFile(filepath.Join(t.TempDir(), "missing.ini"), &c); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected not exist error, got %v", err)
+	}
+	if err := DecodeINI(strings.NewReader(""), c); err == nil {
+		t.Errorf("expected error for non-pointer value")
+	}
+}
@@ -0,0 +1,88 @@
+//go:build !strval_noyaml
+
+/*
+--------------------------------
+@Create 2026/10/18 10:40
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 10:40
+@Description 整数扩展语法测试
+--------------------------------
+本文件包含对Int类型整数扩展语法（进制前缀、数字分隔符、前导零八进制、前导+号与空白）的测试。
+*/
+
+package strval
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestParseIntSyntax 测试parseInt在不同语法选项下的解析结果
+func TestParseIntSyntax(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		syntax IntSyntax
+		want   int64
+		ok     bool
+	}{
+		{"plain", "42", 0, 42, true},
+		{"plus sign", "+42", 0, 42, true},
+		{"surrounding whitespace", "  -42\t", 0, -42, true},
+		{"leading zero is decimal by default", "010", 0, 10, true},
+		{"hex disabled", "0x1F", 0, 0, false},
+		{"hex", "0x1F", IntBasePrefix, 31, true},
+		{"negative hex", "-0X1f", IntBasePrefix, -31, true},
+		{"octal", "0o755", IntBasePrefix, 493, true},
+		{"binary", "0b1010", IntBasePrefix, 10, true},
+		{"prefix only", "0x", IntBasePrefix, 0, false},
+		{"underscore disabled", "1_000_000", 0, 0, false},
+		{"underscore", "1_000_000", IntUnderscore, 1000000, true},
+		{"underscore with prefix", "0xFF_FF", IntBasePrefix | IntUnderscore, 65535, true},
+		{"leading underscore", "_1000", IntUnderscore, 0, false},
+		{"trailing underscore", "1000_", IntUnderscore, 0, false},
+		{"double underscore", "1__000", IntUnderscore, 0, false},
+		{"legacy octal", "010", IntLegacyOctal, 8, true},
+		{"legacy octal invalid digit", "089", IntLegacyOctal, 0, false},
+		{"legacy octal ignores prefix", "0o10", IntLegacyOctal, 0, false},
+		{"all syntax", " +0b1_0 ", IntSyntaxAll, 2, true},
+		{"double sign", "+-1", IntSyntaxAll, 0, false},
+		{"empty", "  ", IntSyntaxAll, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseInt(tt.input, 64, &Options{IntSyntax: tt.syntax})
+			if tt.ok && (err != nil || got != tt.want) {
+				t.Errorf("parseInt(%q) = %d, %v; want %d", tt.input, got, err, tt.want)
+			}
+			if !tt.ok && err == nil {
+				t.Errorf("parseInt(%q) = %d; want error", tt.input, got)
+			}
+		})
+	}
+}
+
+// TestIntExtendedSyntax 测试Int类型在JSON、YAML与数据库路径中使用扩展语法
+func TestIntExtendedSyntax(t *testing.T) {
+	withOptions(t, Options{IntSyntax: IntBasePrefix | IntUnderscore})
+
+	var i Int
+	if err := json.Unmarshal([]byte(`"0x1F"`), &i); err != nil || i != 31 {
+		t.Errorf("JSON \"0x1F\": got %v, err %v", i, err)
+	}
+	if err := yaml.Unmarshal([]byte(`"1_000_000"`), &i); err != nil || i != 1000000 {
+		t.Errorf("YAML \"1_000_000\": got %v, err %v", i, err)
+	}
+	if err := i.Scan(" 0o755 "); err != nil || i != 493 {
+		t.Errorf("Scan(\" 0o755 \"): got %v, err %v", i, err)
+	}
+
+	// 未启用前导零八进制时，"010"仍按十进制解析
+	if err := json.Unmarshal([]byte(`"010"`), &i); err != nil || i != 10 {
+		t.Errorf("JSON \"010\": got %v, err %v", i, err)
+	}
+}
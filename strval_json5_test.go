@@ -0,0 +1,150 @@
+/*
+--------------------------------
+@Create 2026/10/18 23:55
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 23:55
+@Description JSON5子集解析测试
+--------------------------------
+本文件包含对JSON5子集到标准JSON的转换、经由UnmarshalJSON的解析以及错误位置的测试。
+*/
+
+package strval
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+type json5Config struct {
+	Name    String            `json:"name"`
+	Enabled Bool              `json:"enabled"`
+	Port    Int               `json:"port"`
+	Mask    Int               `json:"mask"`
+	Limit   Float             `json:"limit"`
+	Ratio   Float             `json:"ratio"`
+	Tags    []String          `json:"tags"`
+	Extra   map[string]String `json:"extra"`
+	Retries int               `json:"retries"`
+}
+
+// TestDecodeJSON5 测试注释、末尾逗号、单引号、不带引号的键、十六进制与Infinity
+func TestDecodeJSON5(t *testing.T) {
+	withOptions(t, Options{})
+
+	input := `// hand edited
+{
+	name: 'billing \'eu\'', /* inline */
+	enabled: "yes",
+	port: '8080',
+	mask: 0xFF,
+	limit: -Infinity,
+	ratio: .5,
+	tags: ['a', "b",],
+	extra: {$key: 'x\
+y', "quoted": 'tab\there'},
+	retries: +3,
+}
+`
+	var c json5Config
+	if err := DecodeJSON5([]byte(input), &c); err != nil {
+		t.Fatalf("DecodeJSON5 error: %v", err)
+	}
+	if c.Name != "billing 'eu'" || !bool(c.Enabled) || c.Port != 8080 || c.Mask != 255 || !math.IsInf(float64(c.Limit), -1) ||
+		c.Ratio != 0.5 || len(c.Tags) != 2 || c.Tags[1] != "b" || c.Retries != 3 {
+		t.Errorf("unexpected result: %+v", c)
+	}
+	if c.Extra["$key"] != "xy" || c.Extra["quoted"] != "tab\there" {
+		t.Errorf("unexpected extra: %v", c.Extra)
+	}
+
+	// 单次调用选项经由DecodeJSON处理
+	if err := DecodeJSON5([]byte(`{port: "0x10", limit: NaN}`), &c, WithIntSyntax(IntBasePrefix), WithNonFiniteInput(NonFiniteInputReject)); err != nil {
+		t.Fatalf("DecodeJSON5 with options error: %v", err)
+	}
+	if c.Port != 16 || c.Limit != 0 {
+		t.Errorf("unexpected result with options: %+v", c)
+	}
+}
+
+// TestNormalizeJSON5 测试转换结果为标准JSON
+func TestNormalizeJSON5(t *testing.T) {
+	tests := map[string]string{
+		`{a: 1, 'b': [1, 2,], c: 'x"y<'}`:        `{"a":1,"b":[1,2],"c":"x\"y<"}`,
+		`[+1, -.5, 5., 0x1f, -0X10, 1e3, 5.E-2]`: `[1,-0.5,5,31,-16,1e3,5e-2]`,
+		`[Infinity, +Infinity, -Infinity, NaN]`:  `["Infinity","Infinity","-Infinity","NaN"]`,
+		`'\x41é😀\0\v'`:                           `"Aé😀\u0000\u000b"`,
+		"/* a */ {名称: true, _x: null} // end":    `{"名称":true,"_x":null}`,
+	}
+	for input, want := range tests {
+		out, err := NormalizeJSON5([]byte(input))
+		if err != nil || string(out) != want {
+			t.Errorf("NormalizeJSON5(%s) = %s, %v, want %s", input, out, err, want)
+		}
+		if err == nil && !json.Valid(out) {
+			t.Errorf("NormalizeJSON5(%s) produced invalid JSON %s", input, out)
+		}
+	}
+}
+
+// TestJSON5Errors 测试语法错误与类型错误的行号、列号对应原始输入
+func TestJSON5Errors(t *testing.T) {
+	tests := []struct {
+		input        string
+		line, column int
+	}{
+		{"{\n  name: 'open", 2, 9},
+		{"{\n  a: 1\n  b: 2}", 3, 3},
+		{"{\n  /* never closed", 2, 3},
+		{"[1, 2, undefined]", 1, 8},
+		{"{ 1a: 2 }", 1, 3},
+		{"[0x, 1]", 1, 2},
+		{"[012]", 1, 2},
+		{"{\n\t名称: 'a\nb'}", 2, 8},
+		{"{} {}", 1, 4},
+		{"", 1, 1},
+	}
+	for _, tt := range tests {
+		_, err := NormalizeJSON5([]byte(tt.input))
+		var posErr *JSON5Error
+		if !errors.As(err, &posErr) || posErr.Line != tt.line || posErr.Column != tt.column {
+			t.Errorf("NormalizeJSON5(%q) error = %v, want line %d, column %d", tt.input, err, tt.line, tt.column)
+		}
+	}
+
+	var c json5Config
+	err := DecodeJSON5([]byte("{\n  // retries must be a number\n  retries: 'many',\n}"), &c)
+	var posErr *JSON5Error
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &posErr) || posErr.Line != 3 || posErr.Column != 12 || !errors.As(err, &typeErr) {
+		t.Errorf("expected type error at line 3, column 12, got %v", err)
+	}
+
+	// 使用单次调用选项时经过DecodeJSON，嵌套值中的类型错误同样映射到原始位置
+	type item struct {
+		S String `json:"s"`
+		N int    `json:"n"`
+	}
+	var nested struct {
+		Items []item `json:"items"`
+	}
+	inputs := []struct {
+		input        string
+		v            any
+		line, column int
+	}{
+		{"{\n  // retries must be a number\n  retries: 'many',\n}", &c, 3, 12},
+		{"{\n  items: [\n    {s: 'a', n: 1},\n    {s: 'b', n: 'x'},\n  ],\n}", &nested, 4, 17},
+	}
+	for _, tt := range inputs {
+		for _, opts := range [][]Option{nil, {WithBoolOutput(BoolOutputYesNo)}} {
+			err := DecodeJSON5([]byte(tt.input), tt.v, opts...)
+			if !errors.As(err, &posErr) || posErr.Line != tt.line || posErr.Column != tt.column || !errors.As(err, &typeErr) {
+				t.Errorf("DecodeJSON5(%q, %d options) error = %v, want line %d, column %d",
+					tt.input, len(opts), err, tt.line, tt.column)
+			}
+		}
+	}
+}
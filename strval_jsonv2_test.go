@@ -0,0 +1,118 @@
+//go:build goexperiment.jsonv2 && go1.27
+
+/*
+--------------------------------
+@Create 2026/10/19 00:30
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 00:30
+@Description encoding/json/v2流式编解码测试
+--------------------------------
+本文件包含对MarshalJSONTo/UnmarshalJSONFrom与MarshalJSON/UnmarshalJSON结果一致性的测试。
+*/
+
+package strval
+
+import (
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+	"math"
+	"testing"
+)
+
+var jsonv2Inputs = []string{
+	`true`, `false`, `null`, `1`, `-42`, `1.5`, `1e3`, `1e400`, `9223372036854775808`,
+	`"yes"`, `"N"`, `" 12 "`, `"0x1F"`, `"1.25"`, `"NaN"`, `"abc"`, `""`, `"1"`,
+	`[1,2]`, `{"a":1}`,
+}
+
+// checkJSONv2 比较UnmarshalJSONFrom与UnmarshalJSON对同一输入的结果
+func checkJSONv2[T comparable, P interface {
+	*T
+	UnmarshalJSON([]byte) error
+	UnmarshalJSONFrom(*jsontext.Decoder) error
+}](t *testing.T, name string) {
+	t.Helper()
+	for _, input := range jsonv2Inputs {
+		var want, got T
+		if err := P(&want).UnmarshalJSON([]byte(input)); err != nil {
+			t.Fatalf("%s.UnmarshalJSON(%s) error: %v", name, input, err)
+		}
+		if err := jsonv2.Unmarshal([]byte(input), &got); err != nil {
+			t.Errorf("jsonv2.Unmarshal(%s) into %s error: %v", input, name, err)
+			continue
+		}
+		if got != want && !bothNaN(any(got), any(want)) {
+			t.Errorf("jsonv2.Unmarshal(%s) into %s = %v, UnmarshalJSON = %v", input, name, got, want)
+		}
+	}
+}
+
+// TestUnmarshalJSONFrom 测试v2反序列化与UnmarshalJSON的结果一致
+func TestUnmarshalJSONFrom(t *testing.T) {
+	withOptions(t, Options{IntSyntax: IntBasePrefix})
+
+	checkJSONv2[Bool](t, "Bool")
+	checkJSONv2[Int](t, "Int")
+	checkJSONv2[Float](t, "Float")
+	checkJSONv2[String](t, "String")
+	checkJSONv2[NullBool](t, "NullBool")
+}
+
+// bothNaN 判断两个值是否都为NaN的Float
+func bothNaN(a, b any) bool {
+	fa, ok1 := a.(Float)
+	fb, ok2 := b.(Float)
+	return ok1 && ok2 && math.IsNaN(float64(fa)) && math.IsNaN(float64(fb))
+}
+
+// TestJSONv2Struct 测试结构体中的字段、语法错误与Preserved
+func TestJSONv2Struct(t *testing.T) {
+	withOptions(t, Options{})
+
+	var cfg struct {
+		Debug Bool              `json:"debug"`
+		Port  Int               `json:"port"`
+		Ratio Float             `json:"ratio"`
+		Name  String            `json:"name"`
+		Flag  NullBool          `json:"flag"`
+		Raw   Preserved[Int]    `json:"raw"`
+		Skip  Bool              `json:"skip"`
+		List  []Preserved[Bool] `json:"list"`
+	}
+	input := `{"debug":"yes","port":"8080","ratio":"0.5","name":123,"flag":null,"raw":"0042","skip":{"x":[1]},"list":["Y",false]}`
+	if err := jsonv2.Unmarshal([]byte(input), &cfg); err != nil {
+		t.Fatalf("jsonv2.Unmarshal error: %v", err)
+	}
+	if !bool(cfg.Debug) || cfg.Port != 8080 || cfg.Ratio != 0.5 || cfg.Name != "123" || cfg.Flag.Valid ||
+		cfg.Raw.Val != 42 || bool(cfg.Skip) || len(cfg.List) != 2 || !bool(cfg.List[0].Val) {
+		t.Errorf("unexpected result: %+v", cfg)
+	}
+
+	out, err := jsonv2.Marshal(cfg)
+	want := `{"debug":true,"port":8080,"ratio":0.5,"name":"123","flag":null,"raw":"0042","skip":false,"list":["Y",false]}`
+	if err != nil || string(out) != want {
+		t.Errorf("jsonv2.Marshal = %s, %v, want %s", out, err, want)
+	}
+
+	// 语法错误仍然返回
+	if err := jsonv2.Unmarshal([]byte(`{"port": "1",}`), &cfg); err == nil {
+		t.Errorf("expected syntax error")
+	}
+}
+
+// TestMarshalJSONTo 测试v2序列化与MarshalJSON的输出一致
+func TestMarshalJSONTo(t *testing.T) {
+	for _, o := range []Options{{}, {BoolOutput: BoolOutputYesNo, NumberOutput: NumberOutputString}, {NonFiniteOutput: NonFiniteOutputNull}} {
+		withOptions(t, o)
+		for _, v := range []interface {
+			MarshalJSON() ([]byte, error)
+		}{Bool(true), Int(-7), Float(2.5), Float(math.Inf(1)), String(`a"b`), NullBool{}, NullBool{Bool: true, Valid: true}} {
+			want, wantErr := v.MarshalJSON()
+			got, err := jsonv2.Marshal(v)
+			if (err != nil) != (wantErr != nil) || string(got) != string(want) {
+				t.Errorf("jsonv2.Marshal(%#v) with %+v = %s, %v, want %s, %v", v, o, got, err, want, wantErr)
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+/*
+--------------------------------
+@Create 2026/10/18 22:50
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 22:50
+@Description logfmt与DSN键值对编解码测试
+--------------------------------
+本文件包含对logfmt、libpq风格DSN的解析、输出以及错误报告的测试。
+*/
+
+package strval
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type kvLog struct {
+	Level   String        `kv:"level"`
+	Msg     String        `kv:"msg"`
+	Retries Int           `kv:"retries"`
+	Debug   Bool          `kv:"debug"`
+	Latency time.Duration `kv:"latency"`
+	Ratio   Float         `kv:"ratio,omitempty" strval:"decimals=1"`
+}
+
+type kvDSN struct {
+	Host     String   `kv:"host"`
+	Port     Int      `kv:"port"`
+	User     String   `kv:"user"`
+	Password String   `kv:"password"`
+	SSLMode  String   `kv:"sslmode"`
+	Retries  Int      `kv:"retries"`
+	Pooled   NullBool `kv:"pooled,omitempty"`
+}
+
+// TestDecodeLogfmt 测试logfmt解析、引号与转义
+func TestDecodeLogfmt(t *testing.T) {
+	withOptions(t, Options{})
+
+	var l kvLog
+	input := `level=info msg="user \"bob\" logged in\n" retries="3" debug latency=15ms unknown=x RATIO=0.5`
+	if err := DecodeLogfmt([]byte(input), &l); err != nil {
+		t.Fatalf("DecodeLogfmt error: %v", err)
+	}
+	if l.Level != "info" || l.Msg != "user \"bob\" logged in\n" || l.Retries != 3 || !bool(l.Debug) ||
+		l.Latency != 15*time.Millisecond || l.Ratio != 0.5 {
+		t.Errorf("unexpected result: %+v", l)
+	}
+}
+
+// TestDecodeDSN 测试libpq风格DSN解析
+func TestDecodeDSN(t *testing.T) {
+	withOptions(t, Options{})
+
+	var d kvDSN
+	input := `host=db port = 5432 user=app password='p@ss w\'rd\\' sslmode=disable retries="3" pooled=yes`
+	if err := DecodeDSN(input, &d); err != nil {
+		t.Fatalf("DecodeDSN error: %v", err)
+	}
+	if d.Host != "db" || d.Port != 5432 || d.User != "app" || d.Password != `p@ss w'rd\` || d.SSLMode != "disable" ||
+		d.Retries != 3 || !d.Pooled.Valid || !d.Pooled.Bool {
+		t.Errorf("unexpected result: %+v", d)
+	}
+
+	// 双引号包裹的值
+	d = kvDSN{}
+	if err := DecodeDSN(`host=db port=5432 sslmode=disable retries="3"`, &d); err != nil ||
+		d.Host != "db" || d.Port != 5432 || d.SSLMode != "disable" || d.Retries != 3 {
+		t.Errorf("DecodeDSN = %+v, %v", d, err)
+	}
+	if err := DecodeDSN(`password="it's \"x\"" user='say "hi"'`, &d); err != nil ||
+		d.Password != `it's "x"` || d.User != `say "hi"` {
+		t.Errorf("DecodeDSN = %+v, %v", d, err)
+	}
+
+	var kvErr *KVError
+	if err := DecodeDSN(`retries="many"`, &d); !errors.As(err, &kvErr) || kvErr.Key != "retries" || kvErr.Value != "many" {
+		t.Errorf("expected retries error, got %v", err)
+	}
+
+	if err := DecodeDSN("password='' port=0x10", &d, WithIntSyntax(IntBasePrefix)); err != nil || d.Password != "" || d.Port != 16 {
+		t.Errorf("DecodeDSN = %+v, %v", d, err)
+	}
+}
+
+// TestKVSyntaxErrors 测试语法错误包含出错的位置
+func TestKVSyntaxErrors(t *testing.T) {
+	var l kvLog
+	for _, input := range []string{`msg="unterminated`, `=value`, `msg="a"b`, `msg=a"b`, `"key"=1`} {
+		if err := DecodeLogfmt([]byte(input), &l); err == nil || !strings.Contains(err.Error(), "offset") {
+			t.Errorf("DecodeLogfmt(%s) error = %v", input, err)
+		}
+	}
+	var d kvDSN
+	for _, input := range []string{`host`, `host db`, `password='open`, `password="open`, `=x`, `password=a\`} {
+		if err := DecodeDSN(input, &d); err == nil || !strings.Contains(err.Error(), "offset") {
+			t.Errorf("DecodeDSN(%s) error = %v", input, err)
+		}
+	}
+
+	err := DecodeLogfmt([]byte("retries=many debug=maybe"), &l)
+	if err == nil || !strings.Contains(err.Error(), "key retries") || !strings.Contains(err.Error(), "key debug") {
+		t.Errorf("expected aggregated error, got %v", err)
+	}
+	if err := DecodeLogfmt([]byte("a=1"), l); err == nil {
+		t.Errorf("expected error for non-pointer value")
+	}
+}
+
+// TestEncodeKV 测试输出格式与往返
+func TestEncodeKV(t *testing.T) {
+	withOptions(t, Options{})
+
+	l := kvLog{Level: "warn", Msg: "disk \"sda\" full", Retries: 2, Debug: true, Latency: time.Second}
+	out, err := EncodeLogfmt(l, WithBoolOutput(BoolOutputYesNo))
+	if err != nil {
+		t.Fatalf("EncodeLogfmt error: %v", err)
+	}
+	want := `level=warn msg="disk \"sda\" full" retries=2 debug=yes latency=1s`
+	if string(out) != want {
+		t.Errorf("EncodeLogfmt = %s, want %s", out, want)
+	}
+	l.Ratio = 0.5
+	out, _ = EncodeLogfmt(&l)
+	var back kvLog
+	if err := DecodeLogfmt(out, &back); err != nil || back != l {
+		t.Errorf("logfmt round trip = %+v, %v (%s)", back, err, out)
+	}
+
+	d := kvDSN{Host: "db", Port: 5432, User: "app", Password: `it's a\b`}
+	dsn, err := EncodeDSN(d)
+	if err != nil {
+		t.Fatalf("EncodeDSN error: %v", err)
+	}
+	if want := `host=db port=5432 user=app password='it\'s a\\b' sslmode='' retries=0`; dsn != want {
+		t.Errorf("EncodeDSN = %s, want %s", dsn, want)
+	}
+	var backDSN kvDSN
+	if err := DecodeDSN(dsn, &backDSN); err != nil || backDSN != d {
+		t.Errorf("DSN round trip = %+v, %v", backDSN, err)
+	}
+	// 以双引号开头的值需要包裹，否则会被当作引号
+	d.User = `"app"`
+	dsn, _ = EncodeDSN(d)
+	backDSN = kvDSN{}
+	if err := DecodeDSN(dsn, &backDSN); err != nil || backDSN != d {
+		t.Errorf("DSN round trip = %+v, %v (%s)", backDSN, err, dsn)
+	}
+}
@@ -0,0 +1,140 @@
+//go:build !strval_noyaml
+
+/*
+--------------------------------
+@Create 2026/10/18 16:10
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 16:10
+@Description 字符串预处理器管道测试
+--------------------------------
+本文件包含对内置预处理器、全局与按类型注册、单次调用选项以及报告记录的测试。
+*/
+
+package strval
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestBuiltinNormalizers 测试内置预处理器
+func TestBuiltinNormalizers(t *testing.T) {
+	tests := []struct {
+		name  string
+		n     Normalizer
+		input string
+		want  string
+		valid bool
+	}{
+		{"trim", TrimSpace(), "  42 ", "42", true},
+		{"currency prefix", StripCurrency(), "$1,200", "1,200", true},
+		{"currency suffix", StripCurrency(), "12.5 EUR", "12.5", true},
+		{"currency yuan", StripCurrency(), "￥30元", "30", true},
+		{"currency untouched", StripCurrency(), " 42", " 42", true},
+		{"units", StripUnits("sec", "s"), "30 sec", "30", true},
+		{"units case", StripUnits("MB"), "512mb", "512", true},
+		{"null token", NullTokens("N/A", "-"), " n/a ", " n/a ", false},
+		{"not null token", NullTokens("N/A"), "0", "0", true},
+		{"chain", Chain("clean", TrimSpace(), StripCurrency()), " $5 ", "5", true},
+		{"chain stops on null", Chain("clean", NullTokens("-"), StripUnits("x")), "-", "-", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, valid := tt.n.Func(tt.input)
+			if got != tt.want || valid != tt.valid {
+				t.Errorf("%s(%q) = %q, %v; want %q, %v", tt.n.Name, tt.input, got, valid, tt.want, tt.valid)
+			}
+		})
+	}
+}
+
+// TestNormalizerRegistration 测试全局与按类型注册的预处理器在各路径中执行
+func TestNormalizerRegistration(t *testing.T) {
+	withOptions(t, Options{})
+	RegisterNormalizer(NullTokens("N/A"))
+	RegisterTypeNormalizer(KindInt, StripUnits("sec"))
+	RegisterTypeNormalizer(KindFloat, StripCurrency())
+	RegisterTypeNormalizer(KindString, TrimSpace())
+
+	var i Int
+	if err := json.Unmarshal([]byte(`"30 sec"`), &i); err != nil || i != 30 {
+		t.Errorf("JSON \"30 sec\": got %v, err %v", i, err)
+	}
+	if err := yaml.Unmarshal([]byte(`45 sec`), &i); err != nil || i != 45 {
+		t.Errorf("YAML 45 sec: got %v, err %v", i, err)
+	}
+	if err := i.Scan("60 sec"); err != nil || i != 60 {
+		t.Errorf("Scan(\"60 sec\"): got %v, err %v", i, err)
+	}
+	if err := json.Unmarshal([]byte(`"N/A"`), &i); err != nil || i != 0 {
+		t.Errorf("JSON \"N/A\": got %v, err %v", i, err)
+	}
+
+	var f Float
+	if err := f.Scan("$9.99"); err != nil || f != 9.99 {
+		t.Errorf("Scan(\"$9.99\"): got %v, err %v", f, err)
+	}
+	// 按类型注册的预处理器不影响其他类型
+	if err := json.Unmarshal([]byte(`"9 sec"`), &f); err != nil || f != 0 {
+		t.Errorf("JSON \"9 sec\" into Float: got %v, err %v", f, err)
+	}
+
+	n := NullBool{Bool: true, Valid: true}
+	if err := json.Unmarshal([]byte(`"N/A"`), &n); err != nil || n.Valid {
+		t.Errorf("JSON \"N/A\" into NullBool: got %+v, err %v", n, err)
+	}
+
+	// String只执行为KindString注册的预处理器
+	var s String
+	if err := json.Unmarshal([]byte(`"  N/A  "`), &s); err != nil || s != "N/A" {
+		t.Errorf("JSON into String: got %q, err %v", s, err)
+	}
+}
+
+// TestNormalizerReport 测试报告记录预处理器改写的输入
+func TestNormalizerReport(t *testing.T) {
+	type Item struct {
+		Price Float `json:"price" yaml:"price"`
+		Qty   Int   `json:"qty" yaml:"qty"`
+	}
+	type Order struct {
+		Items  []Item `json:"items" yaml:"items"`
+		Active Bool   `json:"active" yaml:"active"`
+	}
+
+	var report Report
+	var order Order
+	data := []byte(`{"items":[{"price":"$5","qty":"2"},{"price":"7","qty":"N/A"}],"active":" yes "}`)
+	err := DecodeJSON(data, &order,
+		WithNormalizers(TrimSpace(), StripCurrency(), NullTokens("N/A")),
+		WithReport(&report),
+	)
+	if err != nil {
+		t.Fatalf("DecodeJSON failed: %v", err)
+	}
+	want := Order{Items: []Item{{Price: 5, Qty: 2}, {Price: 7}}, Active: true}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("unexpected values: %+v", order)
+	}
+
+	wantEntries := []ReportEntry{
+		{Path: "items[0].price", Type: "Float", Input: "$5", Output: "5", Normalizer: "StripCurrency"},
+		{Path: "items[1].qty", Type: "Int", Input: "N/A", Output: "N/A", Normalizer: "NullTokens", Null: true},
+		{Path: "active", Type: "Bool", Input: " yes ", Output: "yes", Normalizer: "TrimSpace"},
+	}
+	if got := report.Entries(); !reflect.DeepEqual(got, wantEntries) {
+		t.Errorf("unexpected report entries:\n got %+v\nwant %+v", got, wantEntries)
+	}
+
+	var yreport Report
+	if err := DecodeYAML([]byte("active: \" no \"\n"), &order, WithNormalizers(TrimSpace()), WithReport(&yreport)); err != nil {
+		t.Fatalf("DecodeYAML failed: %v", err)
+	}
+	if entries := yreport.Entries(); len(entries) != 1 || entries[0].Path != "active" || entries[0].Output != "no" {
+		t.Errorf("unexpected YAML report entries: %+v", entries)
+	}
+}
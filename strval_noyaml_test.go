@@ -0,0 +1,56 @@
+//go:build strval_noyaml
+
+/*
+--------------------------------
+@Create 2026/10/19 02:10
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 02:10
+@Description 不包含YAML支持的构建测试
+--------------------------------
+本文件只在构建标签strval_noyaml下编译，测试各类型不再实现YAML接口，JSON与数据库路径不受影响。
+运行方式：go test -tags strval_noyaml ./...
+*/
+
+package strval
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// conformanceYAML 不包含YAML支持时跳过一致性测试的YAML格式
+func conformanceYAML(*testing.T, conformanceCase, []interface{}) bool {
+	return false
+}
+
+// TestNoYAML 测试各类型不实现YAML接口，JSON与Scan照常工作
+func TestNoYAML(t *testing.T) {
+	withOptions(t, Options{})
+
+	for _, v := range []any{new(Bool), new(Int), new(Float), new(String), new(NullBool), new(Preserved[Int])} {
+		typ := reflect.TypeOf(v)
+		for _, name := range []string{"MarshalYAML", "UnmarshalYAML"} {
+			if _, ok := typ.MethodByName(name); ok {
+				t.Errorf("%s should not have method %s", typ, name)
+			}
+		}
+	}
+
+	var cfg struct {
+		Port  Int            `json:"port"`
+		Debug Bool           `json:"debug"`
+		Raw   Preserved[Int] `json:"raw"`
+	}
+	if err := json.Unmarshal([]byte(`{"port":"8080","debug":"yes","raw":"0042"}`), &cfg); err != nil {
+		t.Fatalf("json.Unmarshal error: %v", err)
+	}
+	if cfg.Port != 8080 || !bool(cfg.Debug) || cfg.Raw.Val != 42 || cfg.Raw.Raw() != `"0042"` {
+		t.Errorf("unexpected result: %+v", cfg)
+	}
+	var b Bool
+	if err := b.Scan(int64(1)); err != nil || !b {
+		t.Errorf("Scan(1): got %v, err %v", b, err)
+	}
+}
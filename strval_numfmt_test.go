@@ -0,0 +1,136 @@
+//go:build !strval_noyaml
+
+/*
+--------------------------------
+@Create 2026/10/18 11:50
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 11:50
+@Description 本地化数字格式测试
+--------------------------------
+本文件包含对千位分组、不同小数点的数字解析测试，以及歧义输入的拒绝行为测试。
+*/
+
+package strval
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// TestParseFloatNumberFormats 测试Float在不同数字格式配置下的解析结果
+func TestParseFloatNumberFormats(t *testing.T) {
+	point := []NumberFormat{NumberFormatPoint}
+	comma := []NumberFormat{NumberFormatComma}
+	space := []NumberFormat{NumberFormatSpace}
+	all := []NumberFormat{NumberFormatPoint, NumberFormatComma, NumberFormatSpace}
+
+	tests := []struct {
+		name    string
+		input   string
+		formats []NumberFormat
+		want    float64
+		ok      bool
+	}{
+		{"no formats", "1,234.56", nil, 0, false},
+		{"plain with formats", "1234", point, 1234, true},
+		{"point grouped", "1,234.56", point, 1234.56, true},
+		{"point multiple groups", "-1,234,567.5", point, -1234567.5, true},
+		{"point plain decimal", "3.5", point, 3.5, true},
+		{"point exponent", "1,234.5e2", point, 123450, true},
+		{"point rejects comma decimal", "1.234,56", point, 0, false},
+		{"point bad group", "1,23.4", point, 0, false},
+		{"point mixed group separators", "1,234 567", point, 0, false},
+		{"comma grouped", "1.234,56", comma, 1234.56, true},
+		{"comma plain decimal", "3,5", comma, 3.5, true},
+		{"comma rejects point decimal", "1234.5", comma, 0, false},
+		{"space grouped", "1 234,56", space, 1234.56, true},
+		{"space nbsp", "1 234 567,5", space, 1234567.5, true},
+		{"all unambiguous point", "1,234.56", all, 1234.56, true},
+		{"all unambiguous comma", "1.234,56", all, 1234.56, true},
+		{"all unambiguous space", "1 234,56", all, 1234.56, true},
+		{"all ambiguous", "1,234", all, 0, false},
+		{"all ambiguous point", "1.234", all, 0, false},
+		{"all same result", "1,5", []NumberFormat{NumberFormatComma, NumberFormatSpace}, 1.5, true},
+	}
+
+	// 非有限值不受数字格式影响
+	if v, err := parseFloat(".inf", &Options{NumberFormats: point}); err != nil || !isNonFinite(v) {
+		t.Errorf("parseFloat(\".inf\") = %v, %v; want +Inf", v, err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFloat(tt.input, &Options{NumberFormats: tt.formats})
+			if tt.ok && (err != nil || got != tt.want) {
+				t.Errorf("parseFloat(%q) = %v, %v; want %v", tt.input, got, err, tt.want)
+			}
+			if !tt.ok && err == nil {
+				t.Errorf("parseFloat(%q) = %v; want error", tt.input, got)
+			}
+		})
+	}
+}
+
+// TestParseIntNumberFormats 测试Int在不同数字格式配置下的解析结果
+func TestParseIntNumberFormats(t *testing.T) {
+	all := &Options{NumberFormats: []NumberFormat{NumberFormatPoint, NumberFormatComma}}
+
+	// 整数只在一个格式下有效时不存在歧义
+	if v, err := parseInt("1,234", 64, &Options{NumberFormats: []NumberFormat{NumberFormatPoint}}); err != nil || v != 1234 {
+		t.Errorf("parseInt(\"1,234\") = %v, %v; want 1234", v, err)
+	}
+	if v, err := parseInt("1.234.567", 64, all); err != nil || v != 1234567 {
+		t.Errorf("parseInt(\"1.234.567\") = %v, %v; want 1234567", v, err)
+	}
+	if _, err := parseInt("1.5", 64, all); err == nil {
+		t.Errorf("parseInt(\"1.5\") should fail")
+	}
+
+	// 同一输入在两种格式下都是整数但结果不同
+	opts := &Options{NumberFormats: []NumberFormat{NumberFormatPoint, {Decimal: '.', Group: "'"}}}
+	if v, err := parseInt("1'234", 64, opts); err != nil || v != 1234 {
+		t.Errorf("parseInt(\"1'234\") = %v, %v; want 1234", v, err)
+	}
+	if _, err := parseFloat("1,234", &Options{NumberFormats: []NumberFormat{NumberFormatPoint, NumberFormatComma}}); !errors.Is(err, ErrAmbiguousNumber) {
+		t.Errorf("expected ErrAmbiguousNumber, got %v", err)
+	}
+}
+
+// TestNumberFormatsGlobalAndPerDecode 测试全局配置与单次解码配置
+func TestNumberFormatsGlobalAndPerDecode(t *testing.T) {
+	type Invoice struct {
+		Total Float `json:"total" yaml:"total"`
+		Count Int   `json:"count" yaml:"count"`
+	}
+
+	// 全局配置
+	withOptions(t, Options{NumberFormats: []NumberFormat{NumberFormatPoint}})
+	var inv Invoice
+	if err := json.Unmarshal([]byte(`{"total":"1,234.56","count":"12,000"}`), &inv); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if inv.Total != 1234.56 || inv.Count != 12000 {
+		t.Errorf("unexpected values: %+v", inv)
+	}
+
+	// 单次解码覆盖全局配置，不影响全局
+	var de Invoice
+	if err := DecodeJSON([]byte(`{"total":"1.234,56","count":"12.000"}`), &de, WithNumberFormats(NumberFormatComma)); err != nil {
+		t.Fatalf("DecodeJSON failed: %v", err)
+	}
+	if de.Total != 1234.56 || de.Count != 12000 {
+		t.Errorf("unexpected values: %+v", de)
+	}
+	var fr Invoice
+	if err := DecodeYAML([]byte("total: 1 234,56\ncount: \"12 000\"\n"), &fr, WithNumberFormats(NumberFormatSpace)); err != nil {
+		t.Fatalf("DecodeYAML failed: %v", err)
+	}
+	if fr.Total != 1234.56 || fr.Count != 12000 {
+		t.Errorf("unexpected values: %+v", fr)
+	}
+	if err := json.Unmarshal([]byte(`{"total":"1.234,56"}`), &inv); err != nil || inv.Total != 0 {
+		t.Errorf("global options should still reject comma decimals, got %v, err %v", inv.Total, err)
+	}
+}
@@ -0,0 +1,205 @@
+//go:build !strval_noyaml
+
+/*
+--------------------------------
+@Create 2026/10/18 17:40
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 17:40
+@Description 可配置的序列化输出形式测试
+--------------------------------
+本文件包含对数字、布尔值与浮点数格式输出形式的测试，覆盖全局选项、EncodeJSON/EncodeYAML的单次调用选项与字段标签。
+*/
+
+package strval
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestOutputGlobal 测试全局输出选项对MarshalJSON/MarshalYAML的影响
+func TestOutputGlobal(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		value    any
+		wantJSON string
+		wantYAML string
+	}{
+		{"int native", Options{}, Int(100), `100`, "100\n"},
+		{"int string", Options{NumberOutput: NumberOutputString}, Int(100), `"100"`, "\"100\"\n"},
+		{"float shortest", Options{}, Float(1.5), `1.5`, "1.5\n"},
+		{"float fixed", Options{FloatFormat: FloatFixed(2)}, Float(1.5), `1.50`, "1.50\n"},
+		{"float fixed zero", Options{FloatFormat: FloatFixed(0)}, Float(2.4), `2`, "2\n"},
+		{"float string", Options{NumberOutput: NumberOutputString, FloatFormat: FloatFixed(1)}, Float(3), `"3.0"`, "\"3.0\"\n"},
+		{"bool native", Options{}, Bool(true), `true`, "true\n"},
+		{"bool string", Options{BoolOutput: BoolOutputString}, Bool(true), `"true"`, "\"true\"\n"},
+		{"bool yesno", Options{BoolOutput: BoolOutputYesNo}, Bool(false), `"no"`, "no\n"},
+		{"bool yn", Options{BoolOutput: BoolOutputYN}, Bool(true), `"Y"`, "Y\n"},
+		{"bool number", Options{BoolOutput: BoolOutputNumber}, Bool(true), `1`, "1\n"},
+		{"null bool valid", Options{BoolOutput: BoolOutputYN}, NullBool{Bool: false, Valid: true}, `"N"`, "N\n"},
+		{"null bool invalid", Options{BoolOutput: BoolOutputYN}, NullBool{}, `null`, "null\n"},
+		{"string unaffected", Options{NumberOutput: NumberOutputString}, String("42"), `"42"`, "\"42\"\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withOptions(t, tt.opts)
+			got, err := json.Marshal(tt.value)
+			if err != nil || string(got) != tt.wantJSON {
+				t.Errorf("json.Marshal(%v) = %s, %v; want %s", tt.value, got, err, tt.wantJSON)
+			}
+			got, err = yaml.Marshal(tt.value)
+			if err != nil || string(got) != tt.wantYAML {
+				t.Errorf("yaml.Marshal(%v) = %q, %v; want %q", tt.value, got, err, tt.wantYAML)
+			}
+		})
+	}
+}
+
+type outputItem struct {
+	Count Int `json:"count" yaml:"count"`
+}
+
+type outputConfig struct {
+	MaxCount Int              `json:"maxCount" yaml:"maxCount" strval:"numbers=string"`
+	Enabled  Bool             `json:"enabled" yaml:"enabled" strval:"bool=yn"`
+	Price    Float            `json:"price" yaml:"price" strval:"decimals=2"`
+	Ratio    Float            `json:"ratio" yaml:"ratio"`
+	Active   Bool             `json:"active" yaml:"active"`
+	Items    []outputItem     `json:"items" yaml:"items"`
+	Limits   map[string]Int   `json:"limits,omitempty" yaml:"limits,omitempty"`
+	Next     *outputItem      `json:"next,omitempty" yaml:"next,omitempty"`
+	Label    string           `json:"label" yaml:"label"`
+	Flags    map[string]bool  `json:"flags,omitempty" yaml:"flags,omitempty"`
+	Extra    map[string]Float `json:"-" yaml:"-"`
+}
+
+// TestEncodeJSON 测试EncodeJSON的单次调用选项与字段标签
+func TestEncodeJSON(t *testing.T) {
+	c := outputConfig{
+		MaxCount: 100, Enabled: true, Price: 1.5, Ratio: 0.25, Active: true,
+		Items:  []outputItem{{Count: 1}},
+		Limits: map[string]Int{"b": 2, "a": 1},
+		Label:  "x",
+	}
+
+	got, err := EncodeJSON(c)
+	if err != nil {
+		t.Fatalf("EncodeJSON failed: %v", err)
+	}
+	want := `{"maxCount":"100","enabled":"Y","price":1.50,"ratio":0.25,"active":true,"items":[{"count":1}],"limits":{"a":1,"b":2},"label":"x"}`
+	if string(got) != want {
+		t.Errorf("default options:\n got %s\nwant %s", got, want)
+	}
+
+	got, err = EncodeJSON(&c, WithNumberOutput(NumberOutputString), WithBoolOutput(BoolOutputNumber), WithFloatFormat(FloatFixed(3)))
+	if err != nil {
+		t.Fatalf("EncodeJSON failed: %v", err)
+	}
+	want = `{"maxCount":"100","enabled":"Y","price":"1.50","ratio":"0.250","active":1,"items":[{"count":"1"}],"limits":{"a":"1","b":"2"},"label":"x"}`
+	if string(got) != want {
+		t.Errorf("call options:\n got %s\nwant %s", got, want)
+	}
+
+	// 结果与encoding/json的输出保持一致
+	var plain map[string]any
+	if err := json.Unmarshal(got, &plain); err != nil {
+		t.Errorf("EncodeJSON produced invalid JSON: %v", err)
+	}
+	if _, err := EncodeJSON(struct {
+		N Int `strval:"numbers=hex"`
+	}{}); err == nil {
+		t.Error("expected error for invalid tag option")
+	}
+}
+
+// TestEncodeYAML 测试EncodeYAML的单次调用选项与字段标签
+func TestEncodeYAML(t *testing.T) {
+	c := outputConfig{
+		MaxCount: 100, Enabled: false, Price: 2, Ratio: 0.5, Active: true,
+		Items: []outputItem{{Count: 3}},
+		Next:  &outputItem{Count: 4},
+	}
+
+	got, err := EncodeYAML(c, WithBoolOutput(BoolOutputYesNo))
+	if err != nil {
+		t.Fatalf("EncodeYAML failed: %v", err)
+	}
+	want := `maxCount: "100"
+enabled: N
+price: 2.00
+ratio: 0.5
+active: yes
+items:
+    - count: 3
+next:
+    count: 4
+label: ""
+`
+	if string(got) != want {
+		t.Errorf("EncodeYAML:\n got %s\nwant %s", got, want)
+	}
+
+	// 输出可以被strval重新读取
+	var back outputConfig
+	if err := yaml.Unmarshal(got, &back); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if back.MaxCount != 100 || bool(back.Enabled) || back.Price != 2 || !bool(back.Active) || back.Next.Count != 4 {
+		t.Errorf("round trip mismatch: %+v", back)
+	}
+}
+
+// TestEncodeNilPointer 测试nil指针字段输出null，与encoding/json一致
+func TestEncodeNilPointer(t *testing.T) {
+	withOptions(t, Options{})
+
+	type nilConfig struct {
+		P *Int      `json:"p" yaml:"p"`
+		B *Bool     `json:"b" yaml:"b"`
+		N *NullBool `json:"n" yaml:"n"`
+		A any       `json:"a" yaml:"a"`
+	}
+	v := nilConfig{A: (*Float)(nil)}
+
+	got, err := EncodeJSON(v)
+	if err != nil {
+		t.Fatalf("EncodeJSON failed: %v", err)
+	}
+	want, _ := json.Marshal(v)
+	if string(got) != string(want) {
+		t.Errorf("EncodeJSON = %s, want %s", got, want)
+	}
+
+	got, err = EncodeYAML(&v)
+	if err != nil {
+		t.Fatalf("EncodeYAML failed: %v", err)
+	}
+	// yaml.v3对接口中的nil指针会调用值接收者的MarshalYAML而panic，这里直接比较期望的输出
+	if want := "p: null\nb: null\nn: null\na: null\n"; string(got) != want {
+		t.Errorf("EncodeYAML = %q, want %q", got, want)
+	}
+}
+
+// TestEncodeYAMLFlowPreserved 测试flow标签不修改Preserved保存的原始节点
+func TestEncodeYAMLFlowPreserved(t *testing.T) {
+	withOptions(t, Options{})
+
+	var v struct {
+		Port Preserved[Int] `yaml:"port,flow"`
+	}
+	if err := yaml.Unmarshal([]byte("port: 0x1F\n"), &v); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := EncodeYAML(&v); err != nil {
+			t.Fatalf("EncodeYAML error: %v", err)
+		}
+	}
+	if v.Port.node.Style&yaml.FlowStyle != 0 {
+		t.Errorf("EncodeYAML modified the preserved node style: %v", v.Port.node.Style)
+	}
+}
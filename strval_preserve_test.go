@@ -0,0 +1,140 @@
+//go:build !strval_noyaml
+
+/*
+--------------------------------
+@Create 2026/10/18 17:00
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 17:00
+@Description 保留原始表示的往返模式测试
+--------------------------------
+本文件包含对Preserved类型在JSON与YAML中往返输出的测试。
+*/
+
+package strval
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+type preservedConfig struct {
+	Port  Preserved[Int]      `json:"port" yaml:"port"`
+	Debug Preserved[Bool]     `json:"debug" yaml:"debug"`
+	Name  Preserved[String]   `json:"name" yaml:"name"`
+	Rate  Preserved[Float]    `json:"rate" yaml:"rate"`
+	Opt   Preserved[NullBool] `json:"opt" yaml:"opt"`
+}
+
+// TestPreservedJSON 测试JSON往返输出
+func TestPreservedJSON(t *testing.T) {
+	input := `{"port":"8080","debug":"yes","name":42,"rate":1.50,"opt":null}`
+	var c preservedConfig
+	if err := json.Unmarshal([]byte(input), &c); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if c.Port.Val != 8080 || !bool(c.Debug.Val) || c.Name.Val != "42" || c.Rate.Val != 1.5 || c.Opt.Val.Valid {
+		t.Errorf("unexpected values: %+v", c)
+	}
+	if c.Port.Modified() || c.Port.Raw() != `"8080"` {
+		t.Errorf("Port: Modified() = %v, Raw() = %q", c.Port.Modified(), c.Port.Raw())
+	}
+
+	out, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(out) != input {
+		t.Errorf("unchanged round trip:\n got %s\nwant %s", out, input)
+	}
+
+	c.Port.Val = 9090
+	c.Debug.Val = false
+	c.Rate.Val = 2
+	c.Opt.Val = NullBool{Bool: true, Valid: true}
+	out, err = json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `{"port":"9090","debug":"false","name":42,"rate":2,"opt":true}`
+	if string(out) != want {
+		t.Errorf("modified round trip:\n got %s\nwant %s", out, want)
+	}
+}
+
+// TestPreservedYAML 测试YAML往返输出，包括引号风格与注释
+func TestPreservedYAML(t *testing.T) {
+	input := "port: \"8080\" # listen port\ndebug: yes\nname: 'abc'\nrate: 1.50\nopt: unknown\n"
+	var c preservedConfig
+	if err := yaml.Unmarshal([]byte(input), &c); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if c.Port.Val != 8080 || !bool(c.Debug.Val) || c.Name.Val != "abc" || c.Rate.Val != 1.5 {
+		t.Errorf("unexpected values: %+v", c)
+	}
+
+	out, err := yaml.Marshal(c)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(out) != input {
+		t.Errorf("unchanged round trip:\n got %s\nwant %s", out, input)
+	}
+
+	c.Port.Val = 9090
+	c.Debug.Val = false
+	c.Name.Val = "xyz"
+	c.Rate.Val = 2.5
+	out, err = yaml.Marshal(c)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := "port: \"9090\" # listen port\ndebug: false\nname: 'xyz'\nrate: 2.5\nopt: unknown\n"
+	if string(out) != want {
+		t.Errorf("modified round trip:\n got %s\nwant %s", out, want)
+	}
+}
+
+// TestPreservedDecode 测试DecodeJSON/DecodeYAML使用单次调用选项并记录原始表示
+func TestPreservedDecode(t *testing.T) {
+	var c preservedConfig
+	if err := DecodeJSON([]byte(`{"port":"0x1F"}`), &c, WithIntSyntax(IntBasePrefix)); err != nil {
+		t.Fatalf("DecodeJSON failed: %v", err)
+	}
+	if c.Port.Val != 31 || c.Port.Modified() {
+		t.Errorf("DecodeJSON: got %v, Modified() = %v", c.Port.Val, c.Port.Modified())
+	}
+	if out, _ := json.Marshal(c.Port); string(out) != `"0x1F"` {
+		t.Errorf("DecodeJSON round trip: got %s", out)
+	}
+
+	if err := DecodeYAML([]byte("port: 0o17\n"), &c, WithIntSyntax(IntBasePrefix)); err != nil {
+		t.Fatalf("DecodeYAML failed: %v", err)
+	}
+	if out, _ := yaml.Marshal(c.Port); string(out) != "0o17\n" {
+		t.Errorf("DecodeYAML round trip: got %q", out)
+	}
+}
+
+// TestPreservedWithoutRaw 测试没有原始表示时与包装类型的行为一致
+func TestPreservedWithoutRaw(t *testing.T) {
+	p := NewPreserved(Int(7))
+	if !p.Modified() || p.Raw() != "" || p.GetValue() != 7 {
+		t.Errorf("unexpected state: %+v", p)
+	}
+	if out, _ := json.Marshal(p); string(out) != "7" {
+		t.Errorf("MarshalJSON: got %s", out)
+	}
+	if out, _ := yaml.Marshal(p); string(out) != "7\n" {
+		t.Errorf("MarshalYAML: got %q", out)
+	}
+
+	// NaN与自身相同，不视为修改
+	var f Preserved[Float]
+	if err := yaml.Unmarshal([]byte(".nan"), &f); err != nil || !math.IsNaN(float64(f.Val)) || f.Modified() {
+		t.Errorf("NaN: got %v, Modified() = %v, err %v", f.Val, f.Modified(), err)
+	}
+}
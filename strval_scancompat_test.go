@@ -0,0 +1,46 @@
+/*
+--------------------------------
+@Create 2026/10/19 01:50
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 01:50
+@Description Scan兼容规则测试
+--------------------------------
+本文件测试Scan保留的早期版本宽松规则：任意非零整数读取为true，浮点数截断为Int。
+*/
+
+package strval
+
+import (
+	"math"
+	"testing"
+)
+
+// TestScanLenient 测试Scan保留早期版本的宽松规则，其余格式不受影响
+func TestScanLenient(t *testing.T) {
+	withOptions(t, Options{})
+
+	var b Bool
+	if err := b.Scan(int64(2)); err != nil || !bool(b) {
+		t.Errorf("Bool.Scan(2) = %v, %v, want true", b, err)
+	}
+	var n NullBool
+	if err := n.Scan(int64(-1)); err != nil || n != (NullBool{Bool: true, Valid: true}) {
+		t.Errorf("NullBool.Scan(-1) = %+v, %v, want true", n, err)
+	}
+	var i Int
+	for value, want := range map[float64]Int{42.5: 42, -1.9: -1, math.NaN(): 0, math.Inf(1): 0, 1e300: 0} {
+		i = 99
+		if err := i.Scan(value); err != nil || i != want {
+			t.Errorf("Int.Scan(%v) = %v, %v, want %v", value, i, err, want)
+		}
+	}
+
+	// JSON仍按严格的规则转换
+	if err := b.UnmarshalJSON([]byte("2")); err != nil || bool(b) {
+		t.Errorf("Bool.UnmarshalJSON(2) = %v, %v, want false", b, err)
+	}
+	if err := i.UnmarshalJSON([]byte("42.5")); err != nil || i != 0 {
+		t.Errorf("Int.UnmarshalJSON(42.5) = %v, %v, want 0", i, err)
+	}
+}
@@ -1,3 +1,5 @@
+//go:build !strval_noyaml
+
 /*
 --------------------------------
 @Create 2025/10/16 11:45
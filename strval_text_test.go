@@ -0,0 +1,141 @@
+/*
+--------------------------------
+@Create 2026/10/18 19:00
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 19:00
+@Description encoding.TextMarshaler与encoding.TextUnmarshaler测试
+--------------------------------
+本文件包含对各类型文本编解码、JSON映射键以及XML属性的测试。
+*/
+
+package strval
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"math"
+	"reflect"
+	"testing"
+)
+
+var (
+	_ encoding.TextMarshaler   = Bool(false)
+	_ encoding.TextUnmarshaler = (*Bool)(nil)
+	_ encoding.TextMarshaler   = Int(0)
+	_ encoding.TextUnmarshaler = (*Int)(nil)
+	_ encoding.TextMarshaler   = Float(0)
+	_ encoding.TextUnmarshaler = (*Float)(nil)
+	_ encoding.TextMarshaler   = String("")
+	_ encoding.TextUnmarshaler = (*String)(nil)
+	_ encoding.TextMarshaler   = NullBool{}
+	_ encoding.TextUnmarshaler = (*NullBool)(nil)
+	_ encoding.TextMarshaler   = Preserved[Int]{}
+	_ encoding.TextUnmarshaler = (*Preserved[Int])(nil)
+)
+
+// TestText 测试文本编解码与UnmarshalJSON的字符串解析一致
+func TestText(t *testing.T) {
+	withOptions(t, Options{IntSyntax: IntBasePrefix})
+
+	tests := []struct {
+		text string
+		dst  encoding.TextUnmarshaler
+		want any
+		out  string
+	}{
+		{"yes", new(Bool), Bool(true), "true"},
+		{"invalid", new(Bool), Bool(false), "false"},
+		{" 42 ", new(Int), Int(42), "42"},
+		{"0x1F", new(Int), Int(31), "31"},
+		{"abc", new(Int), Int(0), "0"},
+		{"3.50", new(Float), Float(3.5), "3.5"},
+		{"-Infinity", new(Float), Float(math.Inf(-1)), "-Infinity"},
+		{"hello", new(String), String("hello"), "hello"},
+		{"N", new(NullBool), NullBool{Valid: true}, "false"},
+		{"", new(NullBool), NullBool{}, ""},
+	}
+	for _, tt := range tests {
+		if err := tt.dst.UnmarshalText([]byte(tt.text)); err != nil {
+			t.Errorf("UnmarshalText(%q) returned error: %v", tt.text, err)
+		}
+		got := reflect.ValueOf(tt.dst).Elem().Interface()
+		if got != tt.want {
+			t.Errorf("UnmarshalText(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+		out, err := got.(encoding.TextMarshaler).MarshalText()
+		if err != nil || string(out) != tt.out {
+			t.Errorf("MarshalText(%v) = %q, %v; want %q", got, out, err, tt.out)
+		}
+	}
+
+	// 与UnmarshalJSON的字符串解析保持一致
+	var fromText, fromJSON Int
+	_ = fromText.UnmarshalText([]byte("0b101"))
+	_ = json.Unmarshal([]byte(`"0b101"`), &fromJSON)
+	if fromText != fromJSON || fromText != 5 {
+		t.Errorf("text %v and JSON %v should match", fromText, fromJSON)
+	}
+}
+
+// TestTextOutputOptions 测试输出选项对MarshalText的影响
+func TestTextOutputOptions(t *testing.T) {
+	withOptions(t, Options{BoolOutput: BoolOutputYN, FloatFormat: FloatFixed(2), NonFiniteOutput: NonFiniteOutputError})
+	if out, _ := Bool(true).MarshalText(); string(out) != "Y" {
+		t.Errorf("Bool MarshalText = %q, want Y", out)
+	}
+	if out, _ := Float(1.5).MarshalText(); string(out) != "1.50" {
+		t.Errorf("Float MarshalText = %q, want 1.50", out)
+	}
+	if _, err := Float(math.NaN()).MarshalText(); err == nil {
+		t.Error("expected error for NaN with NonFiniteOutputError")
+	}
+}
+
+// TestTextMapKeys 测试strval类型作为JSON映射的键
+func TestTextMapKeys(t *testing.T) {
+	withOptions(t, Options{})
+
+	var ints map[Int]String
+	if err := json.Unmarshal([]byte(`{"1":"a"," 2 ":"b"}`), &ints); err != nil {
+		t.Fatalf("Unmarshal map[Int] failed: %v", err)
+	}
+	if !reflect.DeepEqual(ints, map[Int]String{1: "a", 2: "b"}) {
+		t.Errorf("unexpected map: %v", ints)
+	}
+	out, err := json.Marshal(ints)
+	if err != nil || string(out) != `{"1":"a","2":"b"}` {
+		t.Errorf("Marshal map[Int] = %s, %v", out, err)
+	}
+
+	var bools map[Bool]Int
+	if err := json.Unmarshal([]byte(`{"yes":"1","no":0}`), &bools); err != nil {
+		t.Fatalf("Unmarshal map[Bool] failed: %v", err)
+	}
+	if bools[true] != 1 || bools[false] != 0 || len(bools) != 2 {
+		t.Errorf("unexpected map: %v", bools)
+	}
+}
+
+// TestTextXMLAttr 测试strval类型作为XML属性
+func TestTextXMLAttr(t *testing.T) {
+	withOptions(t, Options{})
+	type item struct {
+		XMLName xml.Name `xml:"item"`
+		Count   Int      `xml:"count,attr"`
+		Active  Bool     `xml:"active,attr"`
+		Price   Float    `xml:"price,attr"`
+	}
+	var v item
+	if err := xml.Unmarshal([]byte(`<item count=" 7 " active="Y" price="9.5"/>`), &v); err != nil {
+		t.Fatalf("xml.Unmarshal failed: %v", err)
+	}
+	if v.Count != 7 || !bool(v.Active) || v.Price != 9.5 {
+		t.Errorf("unexpected value: %+v", v)
+	}
+	out, err := xml.Marshal(v)
+	if err != nil || string(out) != `<item count="7" active="true" price="9.5"></item>` {
+		t.Errorf("xml.Marshal = %s, %v", out, err)
+	}
+}
@@ -0,0 +1,158 @@
+//go:build !strval_noyaml
+
+/*
+--------------------------------
+@Create 2026/10/18 13:40
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 13:40
+@Description Unicode规范化测试
+--------------------------------
+本文件包含对全角字符折叠、中文数字解析与中文布尔词识别的测试。
+*/
+
+package strval
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestParseChineseNumber 测试中文数字解析
+func TestParseChineseNumber(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{"零", "0", true},
+		{"十", "10", true},
+		{"十五", "15", true},
+		{"一百", "100", true},
+		{"一百一十", "110", true},
+		{"一千零五", "1005", true},
+		{"壹仟贰佰叁拾肆", "1234", true},
+		{"两万", "20000", true},
+		{"3万", "30000", true},
+		{"1.5亿", "150000000", true},
+		{"一亿三千万", "130000000", true},
+		{"三万亿", "3000000000000", true},
+		{"十二万三千四百五十六", "123456", true},
+		{"二〇二六", "2026", true},
+		{"负三", "-3", true},
+		{"三点一四", "157/50", true},
+		{"百", "", false},
+		{"一二百", "", false},
+		{"万", "", false},
+		{"三点", "", false},
+		{"三个", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseChineseNumber(tt.input)
+			if tt.ok && (err != nil || got.RatString() != tt.want) {
+				t.Errorf("parseChineseNumber(%q) = %v, %v; want %s", tt.input, got, err, tt.want)
+			}
+			if !tt.ok && err == nil {
+				t.Errorf("parseChineseNumber(%q) = %v; want error", tt.input, got.RatString())
+			}
+		})
+	}
+}
+
+// TestUnicodeNumbers 测试Int/Float在启用Unicode规范化时的解析
+func TestUnicodeNumbers(t *testing.T) {
+	all := &Options{Unicode: UnicodeAll}
+
+	if v, err := parseInt("４２", 0, all); err != nil || v != 42 {
+		t.Errorf("parseInt(\"４２\") = %v, %v; want 42", v, err)
+	}
+	if v, err := parseInt("－１２", 0, all); err != nil || v != -12 {
+		t.Errorf("parseInt(\"－１２\") = %v, %v; want -12", v, err)
+	}
+	if v, err := parseInt("　一百　", 0, all); err != nil || v != 100 {
+		t.Errorf("parseInt(\"　一百　\") = %v, %v; want 100", v, err)
+	}
+	if v, err := parseInt("３万", 0, all); err != nil || v != 30000 {
+		t.Errorf("parseInt(\"３万\") = %v, %v; want 30000", v, err)
+	}
+	if _, err := parseInt("三点五", 0, all); err == nil {
+		t.Errorf("parseInt(\"三点五\") should fail for a fraction")
+	}
+	if _, err := parseInt("一百亿", 32, all); err == nil {
+		t.Errorf("parseInt(\"一百亿\") should overflow int32")
+	}
+	if v, err := parseFloat("３．５", all); err != nil || v != 3.5 {
+		t.Errorf("parseFloat(\"３．５\") = %v, %v; want 3.5", v, err)
+	}
+	if v, err := parseFloat("三点五", all); err != nil || v != 3.5 {
+		t.Errorf("parseFloat(\"三点五\") = %v, %v; want 3.5", v, err)
+	}
+
+	// 未启用时保持原有行为
+	if _, err := parseInt("４２", 0, &Options{}); err == nil {
+		t.Errorf("full-width digits should be rejected by default")
+	}
+	if _, err := parseInt("一百", 0, &Options{Unicode: UnicodeFullWidth}); err == nil {
+		t.Errorf("chinese numerals should be rejected without UnicodeChineseNumerals")
+	}
+}
+
+// TestUnicodeBools 测试Bool在启用Unicode规范化时的解析
+func TestUnicodeBools(t *testing.T) {
+	all := &Options{Unicode: UnicodeAll}
+	tests := map[string]bool{
+		"是": true, "否": false, "开启": true, "关闭": false, "启用": true, "禁用": false,
+		"ＹＥＳ": true, "ｎｏ": false, "１": true, " 真 ": true, "假": false,
+	}
+	for input, want := range tests {
+		got, err := parseBool(input, all)
+		if err != nil || got != want {
+			t.Errorf("parseBool(%q) = %v, %v; want %v", input, got, err, want)
+		}
+	}
+	if _, err := parseBool("是", &Options{Unicode: UnicodeFullWidth}); err == nil {
+		t.Errorf("chinese bools should be rejected without UnicodeChineseBools")
+	}
+}
+
+// TestUnicodeDecode 测试全局与单次解码的Unicode选项
+func TestUnicodeDecode(t *testing.T) {
+	type Form struct {
+		Agree  Bool  `json:"agree" yaml:"agree"`
+		Amount Int   `json:"amount" yaml:"amount"`
+		Rate   Float `json:"rate" yaml:"rate"`
+	}
+
+	var form Form
+	data := []byte(`{"agree":"是","amount":"３万","rate":"百分之"}`)
+	if err := DecodeJSON(data, &form, WithUnicode(UnicodeAll)); err != nil {
+		t.Fatalf("DecodeJSON failed: %v", err)
+	}
+	if !form.Agree || form.Amount != 30000 || form.Rate != 0 {
+		t.Errorf("unexpected values: %+v", form)
+	}
+
+	withOptions(t, Options{Unicode: UnicodeAll})
+	var yform Form
+	if err := yaml.Unmarshal([]byte("agree: 否\namount: 一千零五\nrate: ０．２５\n"), &yform); err != nil {
+		t.Fatalf("yaml.Unmarshal failed: %v", err)
+	}
+	if yform.Agree || yform.Amount != 1005 || yform.Rate != 0.25 {
+		t.Errorf("unexpected values: %+v", yform)
+	}
+	var jform Form
+	if err := json.Unmarshal([]byte(`{"agree":"开","amount":"十五"}`), &jform); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if !jform.Agree || jform.Amount != 15 {
+		t.Errorf("unexpected values: %+v", jform)
+	}
+	var b Bool
+	if err := b.Scan("是"); err != nil || !b {
+		t.Errorf("Scan(\"是\") = %v, %v; want true", b, err)
+	}
+}
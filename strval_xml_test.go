@@ -0,0 +1,135 @@
+/*
+--------------------------------
+@Create 2026/10/18 21:30
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 21:30
+@Description XML元素与属性测试
+--------------------------------
+本文件包含对各类型XML元素、属性的解析与输出，以及错误日志位置信息的测试。
+*/
+
+package strval
+
+import (
+	"bytes"
+	"encoding/xml"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+var (
+	_ xml.Marshaler       = Bool(false)
+	_ xml.Unmarshaler     = (*Bool)(nil)
+	_ xml.MarshalerAttr   = Int(0)
+	_ xml.UnmarshalerAttr = (*Int)(nil)
+	_ xml.Marshaler       = Float(0)
+	_ xml.Unmarshaler     = (*String)(nil)
+	_ xml.MarshalerAttr   = NullBool{}
+	_ xml.UnmarshalerAttr = (*NullBool)(nil)
+	_ xml.Marshaler       = Preserved[Int]{}
+	_ xml.Unmarshaler     = (*Preserved[Int])(nil)
+)
+
+type xmlPartner struct {
+	XMLName  xml.Name          `xml:"partner"`
+	Count    Int               `xml:"count,attr"`
+	Verified NullBool          `xml:"verified,attr"`
+	Enabled  Bool              `xml:"enabled"`
+	Limit    Int               `xml:"limit"`
+	Rate     Float             `xml:"rate"`
+	Name     String            `xml:"name"`
+	Optional NullBool          `xml:"optional"`
+	Missing  NullBool          `xml:"missing"`
+	Port     Preserved[Int]    `xml:"port"`
+	Ratio    Preserved[Float]  `xml:"ratio,attr"`
+	Backup   *Bool             `xml:"backup"`
+	Tags     []String          `xml:"tags>tag"`
+	Extra    map[string]string `xml:"-"`
+}
+
+// TestXMLUnmarshal 测试元素与属性按宽松规则解析
+func TestXMLUnmarshal(t *testing.T) {
+	withOptions(t, Options{})
+
+	input := `<partner count="  12 " verified="Y" ratio="0.5" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+	<enabled>Y</enabled>
+	<limit> 100 </limit>
+	<rate>"2.5"</rate>
+	<name> partner </name>
+	<optional></optional>
+	<missing xsi:nil="true"/>
+	<port>8080</port>
+	<backup>no</backup>
+	<tags><tag>a</tag><tag>b</tag></tags>
+</partner>`
+	var p xmlPartner
+	if err := xml.Unmarshal([]byte(input), &p); err != nil {
+		t.Fatalf("xml.Unmarshal failed: %v", err)
+	}
+	if p.Count != 12 || !p.Verified.Valid || !p.Verified.Bool || !bool(p.Enabled) || p.Limit != 100 ||
+		p.Name != " partner " || p.Optional.Valid || p.Missing.Valid || p.Port.Val != 8080 || p.Ratio.Val != 0.5 ||
+		p.Backup == nil || bool(*p.Backup) || len(p.Tags) != 2 || p.Tags[1] != "b" {
+		t.Errorf("unexpected result: %+v", p)
+	}
+	// 元素文本中的引号不是JSON字符串，按普通文本解析失败
+	if p.Rate != 0 {
+		t.Errorf("Rate = %v, want 0", p.Rate)
+	}
+}
+
+// TestXMLMarshal 测试输出形式与文本编解码一致
+func TestXMLMarshal(t *testing.T) {
+	withOptions(t, Options{BoolOutput: BoolOutputYN})
+
+	b := Bool(true)
+	p := xmlPartner{Count: 3, Enabled: true, Limit: 7, Rate: 1.5, Name: "a&b", Port: NewPreserved(Int(80)), Backup: &b}
+	out, err := xml.Marshal(p)
+	if err != nil {
+		t.Fatalf("xml.Marshal failed: %v", err)
+	}
+	want := `<partner count="3" ratio="0"><enabled>Y</enabled><limit>7</limit><rate>1.5</rate>` +
+		`<name>a&amp;b</name><optional></optional><missing></missing><port>80</port><backup>Y</backup><tags></tags></partner>`
+	if string(out) != want {
+		t.Errorf("xml.Marshal = %s\nwant %s", out, want)
+	}
+
+	var back xmlPartner
+	if err := xml.Unmarshal(out, &back); err != nil {
+		t.Fatalf("xml.Unmarshal failed: %v", err)
+	}
+	if back.Count != 3 || !bool(back.Enabled) || back.Name != "a&b" || back.Optional.Valid || back.Port.Val != 80 {
+		t.Errorf("round trip mismatch: %+v", back)
+	}
+}
+
+// TestXMLErrorLog 测试解析失败时记录元素名与位置，XML格式错误仍然返回
+func TestXMLErrorLog(t *testing.T) {
+	withOptions(t, Options{})
+	var buf bytes.Buffer
+	old := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(old) })
+
+	var v struct {
+		Count Int  `xml:"count,attr"`
+		Flag  Bool `xml:"flag"`
+	}
+	if err := xml.Unmarshal([]byte("<v count=\"x\">\n  <flag>maybe</flag>\n</v>"), &v); err != nil {
+		t.Fatalf("xml.Unmarshal failed: %v", err)
+	}
+	log := buf.String()
+	for _, want := range []string{
+		`msg="invalid Int XML value" attribute=count value=x`,
+		`msg="invalid Bool XML value" element=flag value=maybe line=2 column=9`,
+	} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log missing %q:\n%s", want, log)
+		}
+	}
+
+	if err := xml.Unmarshal([]byte("<v><flag>yes</v>"), &v); err == nil {
+		t.Errorf("expected syntax error")
+	}
+}
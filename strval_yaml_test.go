@@ -0,0 +1,199 @@
+//go:build !strval_noyaml
+
+/*
+--------------------------------
+@Create 2026/10/18 18:20
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 18:20
+@Description YAML标签处理与YAML 1.2严格模式测试
+--------------------------------
+本文件包含对显式标签、与yaml.v3一致的默认行为、YAML 1.2严格模式以及错误日志行列号的测试，
+并为一致性测试提供YAML格式的解码。
+*/
+
+package strval
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlScalars 将同一个YAML标量分别反序列化为各strval类型
+func yamlScalars(t *testing.T, input string) (Bool, Int, Float, NullBool, String) {
+	t.Helper()
+	var b Bool
+	var i Int
+	var f Float
+	var n NullBool
+	var s String
+	for _, v := range []any{&b, &i, &f, &n, &s} {
+		if err := yaml.Unmarshal([]byte(input), v); err != nil {
+			t.Fatalf("yaml.Unmarshal(%q) failed: %v", input, err)
+		}
+	}
+	return b, i, f, n, s
+}
+
+// TestYAMLExplicitTags 测试显式标签
+func TestYAMLExplicitTags(t *testing.T) {
+	withOptions(t, Options{})
+
+	if _, i, f, _, s := yamlScalars(t, "!!str 123"); i != 123 || f != 123 || s != "123" {
+		t.Errorf("!!str 123: got %v, %v, %q", i, f, s)
+	}
+	if _, i, f, _, _ := yamlScalars(t, `!!int "12"`); i != 12 || f != 12 {
+		t.Errorf(`!!int "12": got %v, %v`, i, f)
+	}
+	if _, i, f, _, s := yamlScalars(t, "!!float 3"); i != 3 || f != 3 || s != "3" {
+		t.Errorf("!!float 3: got %v, %v, %q", i, f, s)
+	}
+	if b, _, _, n, _ := yamlScalars(t, "!!str true"); !bool(b) || !n.Valid || !n.Bool {
+		t.Errorf("!!str true: got %v, %+v", b, n)
+	}
+	if b, _, _, n, s := yamlScalars(t, "!!bool TRUE"); !bool(b) || !n.Bool || s != "TRUE" {
+		t.Errorf("!!bool TRUE: got %v, %+v, %q", b, n, s)
+	}
+	// 默认与yaml.v3一致，yes是合法的!!bool
+	if b, _, _, n, s := yamlScalars(t, "!!bool yes"); !bool(b) || !n.Bool || s != "yes" {
+		t.Errorf("!!bool yes: got %v, %+v, %q", b, n, s)
+	}
+	// 非整数的浮点数不能解析为Int
+	if _, i, f, _, _ := yamlScalars(t, "1.5"); i != 0 || f != 1.5 {
+		t.Errorf("1.5: got %v, %v", i, f)
+	}
+
+	// !!null只能通过DecodeYAML传入，yaml.v3遇到null时不会调用UnmarshalYAML
+	type nullable struct {
+		B Bool     `yaml:"b"`
+		I Int      `yaml:"i"`
+		F Float    `yaml:"f"`
+		N NullBool `yaml:"n"`
+		S String   `yaml:"s"`
+	}
+	v := nullable{B: true, I: 1, F: 1, N: NullBool{Bool: true, Valid: true}, S: "x"}
+	if err := DecodeYAML([]byte("b: !!null\ni: ~\nf: null\nn: ~\ns: !!null ''\n"), &v); err != nil {
+		t.Fatalf("DecodeYAML failed: %v", err)
+	}
+	if v != (nullable{}) {
+		t.Errorf("!!null: got %+v", v)
+	}
+}
+
+// TestYAML12Strict 测试与yaml.v3一致的默认行为与YAML 1.2严格模式的差异
+func TestYAML12Strict(t *testing.T) {
+	type yamlCase struct {
+		input    string
+		wantBool bool
+		wantNull NullBool
+		wantInt  Int
+	}
+
+	// 默认结果与yaml.v3解码到bool、int时相同
+	withOptions(t, Options{})
+	for _, tt := range []yamlCase{
+		{"on", true, NullBool{Bool: true, Valid: true}, 0},
+		{"OFF", false, NullBool{Bool: false, Valid: true}, 0},
+		{"Y", true, NullBool{Bool: true, Valid: true}, 0},
+		{"0755", false, NullBool{}, 493},
+		{"-010", false, NullBool{}, -8},
+		{"08", false, NullBool{}, 8},
+		{"0o755", false, NullBool{}, 493},
+		{"0x1F", false, NullBool{}, 31},
+		{"1_000", false, NullBool{}, 1000},
+	} {
+		b, i, _, n, _ := yamlScalars(t, tt.input)
+		if bool(b) != tt.wantBool || n != tt.wantNull || i != tt.wantInt {
+			t.Errorf("default %q: got %v, %+v, %v", tt.input, b, n, i)
+		}
+		var nb bool
+		var ni int
+		_ = yaml.Unmarshal([]byte(tt.input), &nb)
+		_ = yaml.Unmarshal([]byte(tt.input), &ni)
+		if bool(b) != nb || int(i) != ni {
+			t.Errorf("default %q: got %v, %v, yaml.v3 gives %v, %v", tt.input, b, i, nb, ni)
+		}
+	}
+
+	// 带引号的值仍是字符串，按字符串规则解析
+	b, i, _, _, s := yamlScalars(t, `"on"`)
+	if bool(b) || s != "on" {
+		t.Errorf(`default "on": got %v, %q`, b, s)
+	}
+	if _, i, _, _, _ = yamlScalars(t, `"0755"`); i != 755 {
+		t.Errorf(`default "0755": got %v`, i)
+	}
+
+	withOptions(t, Options{YAML12: true})
+	for _, tt := range []yamlCase{
+		{"on", false, NullBool{}, 0},
+		{"OFF", false, NullBool{}, 0},
+		{"0755", false, NullBool{}, 755},
+		{"0o755", false, NullBool{}, 493},
+		{"0x1F", false, NullBool{}, 31},
+		{"1_000", false, NullBool{}, 1000},
+	} {
+		b, i, _, n, _ := yamlScalars(t, tt.input)
+		if bool(b) != tt.wantBool || n != tt.wantNull || i != tt.wantInt {
+			t.Errorf("YAML 1.2 %q: got %v, %+v, %v", tt.input, b, n, i)
+		}
+	}
+	// YAML 1.2中yes不是合法的!!bool
+	if b, _, _, n, s := yamlScalars(t, "!!bool yes"); bool(b) || n.Valid || s != "yes" {
+		t.Errorf("YAML 1.2 !!bool yes: got %v, %+v, %q", b, n, s)
+	}
+
+	// 单次调用选项
+	withOptions(t, Options{})
+	var cfg struct {
+		Mode Int  `yaml:"mode"`
+		On   Bool `yaml:"on"`
+	}
+	if err := DecodeYAML([]byte("mode: 0644\non: on\n"), &cfg, WithYAML12(true)); err != nil {
+		t.Fatalf("DecodeYAML failed: %v", err)
+	}
+	if cfg.Mode != 644 || bool(cfg.On) {
+		t.Errorf("DecodeYAML with YAML12: got %+v", cfg)
+	}
+}
+
+// TestYAMLErrorPosition 测试错误日志包含YAML节点的行列号
+func TestYAMLErrorPosition(t *testing.T) {
+	withOptions(t, Options{})
+	var buf bytes.Buffer
+	old := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(old) })
+
+	var cfg struct {
+		Port Int  `yaml:"port"`
+		Flag Bool `yaml:"flag"`
+	}
+	if err := yaml.Unmarshal([]byte("port: 8080\nflag:   maybe\n"), &cfg); err != nil {
+		t.Fatalf("yaml.Unmarshal failed: %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "line=2") || !strings.Contains(out, "column=9") {
+		t.Errorf("log should contain the node position, got %q", out)
+	}
+}
+
+// conformanceYAML 将一致性测试的YAML输入解码到各目标值
+//
+// 说明：直接传入节点，yaml.Unmarshal遇到null时不会调用UnmarshalYAML
+func conformanceYAML(t *testing.T, c conformanceCase, targets []interface{}) bool {
+	t.Helper()
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(c.yaml), &doc); err != nil {
+		t.Fatalf("%s: invalid YAML %q: %v", c.name, c.yaml, err)
+	}
+	for _, v := range targets {
+		if err := v.(yaml.Unmarshaler).UnmarshalYAML(doc.Content[0]); err != nil {
+			t.Errorf("%s: UnmarshalYAML(%s) into %T error: %v", c.name, c.yaml, v, err)
+		}
+	}
+	return true
+}
@@ -0,0 +1,145 @@
+/*
+--------------------------------
+@Create 2026/10/18 14:50
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 14:50
+@Description strval字段标签解析
+--------------------------------
+本文件实现了字段级选项标签的解析。标签格式为逗号分隔的key=value，列表值使用"|"分隔，例如：
+
+	Enabled  strval.Bool  `json:"enabled" strval:"true=on|enabled,false=off|disabled,unknown=n/a"`
+	MaxCount strval.Int   `json:"maxCount" strval:"numbers=string"`
+	Price    strval.Float `json:"price" strval:"decimals=2"`
+
+字段标签在DecodeJSON/DecodeYAML/EncodeJSON/EncodeYAML等按结构体遍历的入口中生效，覆盖本次调用的选项。
+*/
+
+package strval
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// tagName strval字段标签的键名
+const tagName = "strval"
+
+// tagHandlers 各标签选项的处理函数
+var tagHandlers = map[string]func(o *Options, value string) error{
+	"true": func(o *Options, value string) error {
+		v := *o.boolVocabulary()
+		v.True = splitTagList(value)
+		o.BoolVocabulary = &v
+		return nil
+	},
+	"false": func(o *Options, value string) error {
+		v := *o.boolVocabulary()
+		v.False = splitTagList(value)
+		o.BoolVocabulary = &v
+		return nil
+	},
+	"unknown": func(o *Options, value string) error {
+		v := *o.boolVocabulary()
+		v.Unknown = splitTagList(value)
+		o.BoolVocabulary = &v
+		return nil
+	},
+	"numbers": func(o *Options, value string) error {
+		switch value {
+		case "native":
+			o.NumberOutput = NumberOutputNative
+		case "string":
+			o.NumberOutput = NumberOutputString
+		default:
+			return fmt.Errorf("invalid number output %q", value)
+		}
+		return nil
+	},
+	"bool": func(o *Options, value string) error {
+		out, ok := boolOutputNames[value]
+		if !ok {
+			return fmt.Errorf("invalid bool output %q", value)
+		}
+		o.BoolOutput = out
+		return nil
+	},
+	"decimals": func(o *Options, value string) error {
+		f, err := parseFloatFormat(value)
+		if err != nil {
+			return err
+		}
+		o.FloatFormat = f
+		return nil
+	},
+}
+
+// splitTagList 拆分以"|"分隔的标签列表值
+func splitTagList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, "|")
+}
+
+// tagCache 缓存已解析的标签
+var tagCache sync.Map
+
+// parseTag 解析strval标签为选项列表
+// 参数:
+//   - tag: strval标签的值
+//
+// 返回值:
+//   - []Option: 按标签顺序应用的选项
+//   - error: 标签格式错误或包含未知的选项
+func parseTag(tag string) ([]Option, error) {
+	if cached, ok := tagCache.Load(tag); ok {
+		return cached.([]Option), nil
+	}
+
+	var opts []Option
+	for _, item := range strings.Split(tag, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(item, "=")
+		handler, ok := tagHandlers[key]
+		if !ok {
+			return nil, fmt.Errorf("strval: unknown tag option %q", key)
+		}
+		// 提前校验选项值，使格式错误在解析标签时即可发现
+		if err := handler(&Options{}, value); err != nil {
+			return nil, fmt.Errorf("strval: invalid tag option %q: %w", item, err)
+		}
+		opts = append(opts, func(o *Options) { _ = handler(o, value) })
+	}
+	tagCache.Store(tag, opts)
+	return opts, nil
+}
+
+// fieldOptions 根据字段的strval标签生成字段级选项
+// 参数:
+//   - o: 本次调用的选项
+//   - tag: 字段的结构体标签
+//
+// 返回值:
+//   - *Options: 字段生效的选项，没有strval标签时直接返回o
+//   - error: 标签格式错误
+func fieldOptions(o *Options, tag reflect.StructTag) (*Options, error) {
+	t, ok := tag.Lookup(tagName)
+	if !ok || t == "" {
+		return o, nil
+	}
+	opts, err := parseTag(t)
+	if err != nil {
+		return nil, err
+	}
+	fo := *o
+	for _, opt := range opts {
+		opt(&fo)
+	}
+	return &fo, nil
+}
@@ -0,0 +1,150 @@
+/*
+--------------------------------
+@Create 2026/10/18 19:00
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 19:00
+@Description encoding.TextMarshaler与encoding.TextUnmarshaler支持
+--------------------------------
+本文件为各strval类型实现encoding.TextMarshaler与encoding.TextUnmarshaler接口，
+使其可以作为JSON映射的键、XML属性，并可用于依赖这两个接口的TOML、环境变量等库。
+文本作为字符串标量交给各类型共用的coerce方法，与UnmarshalJSON中字符串的解析规则相同（预处理器、词汇表、扩展语法等），
+与其他反序列化方法一致，解析失败时置为零值并记录错误日志。
+*/
+
+package strval
+
+import (
+	"encoding"
+	"fmt"
+	"log/slog"
+	"strconv"
+)
+
+// optionTextMarshaler 由各strval类型实现，支持按指定选项输出文本
+type optionTextMarshaler interface {
+	marshalText(o *Options) ([]byte, error)
+}
+
+// MarshalText 实现encoding.TextMarshaler接口
+//
+// 说明：默认输出"true"/"false"，BoolOutputYesNo与BoolOutputYN等输出形式同样适用
+func (b Bool) MarshalText() ([]byte, error) {
+	return b.marshalText(currentOptions())
+}
+
+// marshalText 按指定选项输出文本，供MarshalText与CSVWriter共用
+func (b Bool) marshalText(o *Options) ([]byte, error) {
+	return []byte(o.boolText(bool(b))), nil
+}
+
+// UnmarshalText 实现encoding.TextUnmarshaler接口，解析规则与字符串形式的JSON值相同
+func (b *Bool) UnmarshalText(text []byte) error {
+	if err := b.coerce(textScalar(bytesView(text), true), currentOptions()); err != nil {
+		slog.Error("invalid Bool text value", "value", string(text), "error", err)
+	}
+	return nil
+}
+
+// MarshalText 实现encoding.TextMarshaler接口
+func (i Int) MarshalText() ([]byte, error) {
+	return i.marshalText(currentOptions())
+}
+
+// marshalText 按指定选项输出文本，供MarshalText与CSVWriter共用
+func (i Int) marshalText(o *Options) ([]byte, error) {
+	return strconv.AppendInt(nil, int64(i), 10), nil
+}
+
+// UnmarshalText 实现encoding.TextUnmarshaler接口，解析规则与字符串形式的JSON值相同
+func (i *Int) UnmarshalText(text []byte) error {
+	if err := i.coerce(textScalar(bytesView(text), true), currentOptions()); err != nil {
+		slog.Error("invalid Int text value", "value", string(text), "error", err)
+	}
+	return nil
+}
+
+// MarshalText 实现encoding.TextMarshaler接口
+//
+// 说明：数字格式由全局选项FloatFormat控制；NaN/±Inf输出"NaN"、"Infinity"、"-Infinity"，
+// NonFiniteOutput为NonFiniteOutputNull时输出空文本，为NonFiniteOutputError时返回错误
+func (f Float) MarshalText() ([]byte, error) {
+	return f.marshalText(currentOptions())
+}
+
+// marshalText 按指定选项输出文本，供MarshalText与CSVWriter共用
+func (f Float) marshalText(o *Options) ([]byte, error) {
+	v := float64(f)
+	if isNonFinite(v) {
+		switch o.NonFiniteOutput {
+		case NonFiniteOutputNull:
+			return []byte{}, nil
+		case NonFiniteOutputError:
+			return nil, fmt.Errorf("strval: unsupported Float value %s", nonFiniteString(v))
+		}
+		return []byte(nonFiniteString(v)), nil
+	}
+	return []byte(o.floatText(v)), nil
+}
+
+// UnmarshalText 实现encoding.TextUnmarshaler接口，解析规则与字符串形式的JSON值相同
+func (f *Float) UnmarshalText(text []byte) error {
+	if err := f.coerce(textScalar(bytesView(text), true), currentOptions()); err != nil {
+		slog.Error("invalid Float text value", "value", string(text), "error", err)
+	}
+	return nil
+}
+
+// MarshalText 实现encoding.TextMarshaler接口
+func (s String) MarshalText() ([]byte, error) {
+	return s.marshalText(currentOptions())
+}
+
+// marshalText 按指定选项输出文本，供MarshalText与CSVWriter共用
+func (s String) marshalText(o *Options) ([]byte, error) {
+	return []byte(s), nil
+}
+
+// UnmarshalText 实现encoding.TextUnmarshaler接口，执行为KindString注册的预处理器
+func (s *String) UnmarshalText(text []byte) error {
+	return s.coerce(textScalar(bytesView(text), true), currentOptions())
+}
+
+// MarshalText 实现encoding.TextMarshaler接口，无效值输出空文本
+func (n NullBool) MarshalText() ([]byte, error) {
+	return n.marshalText(currentOptions())
+}
+
+// marshalText 按指定选项输出文本，供MarshalText与CSVWriter共用
+func (n NullBool) marshalText(o *Options) ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+	return []byte(o.boolText(n.Bool)), nil
+}
+
+// UnmarshalText 实现encoding.TextUnmarshaler接口，空文本与未知值词汇得到无效值
+func (n *NullBool) UnmarshalText(text []byte) error {
+	if err := n.coerce(textScalar(bytesView(text), true), currentOptions()); err != nil {
+		slog.Error("invalid NullBool text value", "value", string(text), "error", err)
+	}
+	return nil
+}
+
+// MarshalText 实现encoding.TextMarshaler接口，输出包装值的文本
+func (p Preserved[T]) MarshalText() ([]byte, error) {
+	return p.marshalText(currentOptions())
+}
+
+// marshalText 按指定选项输出包装值的文本
+func (p Preserved[T]) marshalText(o *Options) ([]byte, error) {
+	return any(p.Val).(optionTextMarshaler).marshalText(o)
+}
+
+// UnmarshalText 实现encoding.TextUnmarshaler接口
+//
+// 说明：文本没有引号风格等可保留的信息，因此不记录原始表示，Modified始终返回true
+func (p *Preserved[T]) UnmarshalText(text []byte) error {
+	*p = Preserved[T]{}
+	return any(&p.Val).(encoding.TextUnmarshaler).UnmarshalText(text)
+}
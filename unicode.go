@@ -0,0 +1,314 @@
+/*
+--------------------------------
+@Create 2026/10/18 13:40
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 13:40
+@Description Unicode规范化：全角字符、中文数字与中文布尔词
+--------------------------------
+本文件实现了可选的Unicode规范化层，用于处理中文输入法产生的输入：
+1. 将全角数字、符号与字母折叠为半角，如"４２"→"42"
+2. 解析中文数字，包括大写数字与万/亿量级，如"一百"、"3万"、"壹仟零伍"、"三点一四"
+3. 识别常用的中文布尔词，如"是"/"否"、"开启"/"关闭"
+*/
+
+package strval
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// UnicodeMode 定义启用的Unicode规范化功能，可按位组合
+type UnicodeMode uint
+
+const (
+	// UnicodeFullWidth 将全角ASCII字符（数字、正负号、小数点、字母等）与全角空格折叠为半角
+	UnicodeFullWidth UnicodeMode = 1 << iota
+	// UnicodeChineseNumerals 解析中文数字（含大写数字与万/亿量级）
+	UnicodeChineseNumerals
+	// UnicodeChineseBools 识别常用的中文布尔词
+	UnicodeChineseBools
+)
+
+// UnicodeAll 启用所有Unicode规范化功能
+const UnicodeAll = UnicodeFullWidth | UnicodeChineseNumerals | UnicodeChineseBools
+
+// errChineseNumeral 中文数字格式错误
+var errChineseNumeral = errors.New("invalid chinese numeral")
+
+// chineseTrueWords 与 chineseFalseWords 启用UnicodeChineseBools时识别的布尔词
+var (
+	chineseTrueWords  = []string{"是", "真", "对", "开", "开启", "启用", "有", "正确"}
+	chineseFalseWords = []string{"否", "假", "错", "关", "关闭", "禁用", "无", "不是", "错误"}
+)
+
+// chineseDigits 中文数字字符与数值的对应关系
+var chineseDigits = map[rune]int64{
+	'零': 0, '〇': 0,
+	'一': 1, '壹': 1,
+	'二': 2, '贰': 2, '貳': 2, '两': 2, '兩': 2,
+	'三': 3, '叁': 3, '參': 3,
+	'四': 4, '肆': 4,
+	'五': 5, '伍': 5,
+	'六': 6, '陆': 6, '陸': 6,
+	'七': 7, '柒': 7,
+	'八': 8, '捌': 8,
+	'九': 9, '玖': 9,
+}
+
+// chineseUnits 段内单位（十、百、千）
+var chineseUnits = map[rune]int64{
+	'十': 10, '拾': 10,
+	'百': 100, '佰': 100,
+	'千': 1000, '仟': 1000,
+}
+
+// foldWidth 将全角ASCII字符（U+FF01~U+FF5E）折叠为对应的半角字符，全角空格折叠为普通空格
+func foldWidth(s string) string {
+	if !strings.ContainsFunc(s, isFullWidth) {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 0xFF01 && r <= 0xFF5E:
+			return r - 0xFEE0
+		case r == 0x3000:
+			return ' '
+		default:
+			return r
+		}
+	}, s)
+}
+
+// isFullWidth 判断字符是否为可折叠的全角字符
+func isFullWidth(r rune) bool {
+	return (r >= 0xFF01 && r <= 0xFF5E) || r == 0x3000
+}
+
+// normalizeNumberUnicode 按选项对数字字符串做Unicode规范化
+// 返回值:
+//   - string: 折叠全角字符后的字符串
+//   - *big.Rat: 输入为中文数字时的解析结果，否则为nil
+//   - error: 中文数字格式错误
+func normalizeNumberUnicode(s string, o *Options) (string, *big.Rat, error) {
+	if o.Unicode&UnicodeFullWidth != 0 {
+		s = foldWidth(s)
+	}
+	if o.Unicode&UnicodeChineseNumerals != 0 && strings.ContainsFunc(s, isChineseNumeral) {
+		r, err := parseChineseNumber(strings.TrimSpace(s))
+		return s, r, err
+	}
+	return s, nil, nil
+}
+
+// isChineseNumeral 判断字符是否属于中文数字（数字、单位、量级、符号或小数点）
+func isChineseNumeral(r rune) bool {
+	if _, ok := chineseDigits[r]; ok {
+		return true
+	}
+	if _, ok := chineseUnits[r]; ok {
+		return true
+	}
+	switch r {
+	case '万', '萬', '亿', '億', '负', '負', '正', '点', '點':
+		return true
+	}
+	return false
+}
+
+// parseChineseNumber 解析中文数字，结果以精确的有理数表示
+// 参数:
+//   - s: 输入字符串，允许混合阿拉伯数字，如"3万"、"1.5亿"
+//
+// 返回值:
+//   - *big.Rat: 解析结果
+//   - error: 格式错误
+//
+// 说明:
+//   - 带单位的写法按量级累加，如"一亿三千万"、"一千零五"、"十五"
+//   - 不带单位的多位数字按位读取，如"二〇二六"
+//   - "点"之后的部分按位读取为小数，如"三点一四"
+func parseChineseNumber(s string) (*big.Rat, error) {
+	negative := false
+	if r, size := utf8.DecodeRuneInString(s); r == '负' || r == '負' || r == '-' {
+		negative, s = true, s[size:]
+	} else if r == '正' || r == '+' {
+		s = s[size:]
+	}
+	if s == "" {
+		return nil, errChineseNumeral
+	}
+
+	intPart, fracPart, hasPoint := cutAny(s, "点點")
+	result, err := parseChineseInteger(intPart)
+	if err != nil {
+		return nil, err
+	}
+	if hasPoint {
+		digits, ok := chinesePositional(fracPart)
+		if !ok {
+			return nil, errChineseNumeral
+		}
+		frac, _ := new(big.Rat).SetString("0." + digits)
+		result.Add(result, frac)
+	}
+	if negative {
+		result.Neg(result)
+	}
+	return result, nil
+}
+
+// parseChineseInteger 解析"点"之前的部分
+func parseChineseInteger(s string) (*big.Rat, error) {
+	if s == "" {
+		return nil, errChineseNumeral
+	}
+	if digits, ok := chinesePositional(s); ok {
+		r, _ := new(big.Rat).SetString(digits)
+		return r, nil
+	}
+
+	// total为已完成的亿级部分，wan为当前亿段内的万级部分，section为当前万段内的值，num为当前数字
+	total, wan, section := new(big.Rat), new(big.Rat), new(big.Rat)
+	var num *big.Rat
+	lastDigit, lastZero := false, false
+	for s != "" {
+		r, size := utf8.DecodeRuneInString(s)
+
+		// 阿拉伯数字（可带小数）作为一个整体
+		if r >= '0' && r <= '9' {
+			end := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+			if end < 0 {
+				end = len(s)
+			}
+			if lastDigit && !lastZero {
+				return nil, errChineseNumeral
+			}
+			n, ok := new(big.Rat).SetString(s[:end])
+			if !ok {
+				return nil, errChineseNumeral
+			}
+			num, lastDigit, lastZero = n, true, false
+			s = s[end:]
+			continue
+		}
+		s = s[size:]
+
+		if d, ok := chineseDigits[r]; ok {
+			if lastDigit && !lastZero {
+				return nil, errChineseNumeral
+			}
+			num, lastDigit, lastZero = big.NewRat(d, 1), true, d == 0
+			continue
+		}
+		lastDigit, lastZero = false, false
+
+		if u, ok := chineseUnits[r]; ok {
+			if num == nil {
+				// "十五"、"一百十"中的"十"可省略前面的"一"
+				if u != 10 {
+					return nil, errChineseNumeral
+				}
+				num = big.NewRat(1, 1)
+			}
+			section.Add(section, num.Mul(num, big.NewRat(u, 1)))
+			num = nil
+			continue
+		}
+
+		switch r {
+		case '万', '萬':
+			if num != nil {
+				section.Add(section, num)
+			}
+			if section.Sign() == 0 && wan.Sign() == 0 && total.Sign() == 0 {
+				return nil, errChineseNumeral
+			}
+			wan.Add(wan, section)
+			section, num = new(big.Rat), nil
+		case '亿', '億':
+			if num != nil {
+				section.Add(section, num)
+			}
+			part := new(big.Rat).Mul(wan, big.NewRat(10000, 1))
+			part.Add(part, section).Add(part, total)
+			if part.Sign() == 0 {
+				return nil, errChineseNumeral
+			}
+			total = part.Mul(part, big.NewRat(100000000, 1))
+			wan, section, num = new(big.Rat), new(big.Rat), nil
+		default:
+			return nil, errChineseNumeral
+		}
+	}
+
+	if num != nil {
+		section.Add(section, num)
+	}
+	result := new(big.Rat).Mul(wan, big.NewRat(10000, 1))
+	return result.Add(result, section).Add(result, total), nil
+}
+
+// ratToInt 将中文数字的解析结果转换为指定位数的整数
+func ratToInt(r *big.Rat, bitSize int) (int64, error) {
+	if !r.IsInt() {
+		return 0, strconv.ErrSyntax
+	}
+	if bitSize == 0 {
+		bitSize = strconv.IntSize
+	}
+	n := r.Num()
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bitSize-1))
+	if n.Cmp(limit) >= 0 || n.Cmp(limit.Neg(limit)) < 0 {
+		return 0, strconv.ErrRange
+	}
+	return n.Int64(), nil
+}
+
+// chinesePositional 将不带单位的中文或阿拉伯数字按位转换为阿拉伯数字串，如"二〇二六"→"2026"
+func chinesePositional(s string) (string, bool) {
+	if s == "" {
+		return "", false
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+			continue
+		}
+		d, ok := chineseDigits[r]
+		if !ok {
+			return "", false
+		}
+		b.WriteByte(byte('0' + d))
+	}
+	return b.String(), true
+}
+
+// cutAny 在第一个属于chars的字符处切分字符串
+func cutAny(s, chars string) (before, after string, found bool) {
+	if i := strings.IndexAny(s, chars); i >= 0 {
+		_, size := utf8.DecodeRuneInString(s[i:])
+		return s[:i], s[i+size:], true
+	}
+	return s, "", false
+}
+
+// parseChineseBool 识别中文布尔词
+func parseChineseBool(s string) (bool, bool) {
+	for _, w := range chineseTrueWords {
+		if s == w {
+			return true, true
+		}
+	}
+	for _, w := range chineseFalseWords {
+		if s == w {
+			return false, true
+		}
+	}
+	return false, false
+}
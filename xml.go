@@ -0,0 +1,314 @@
+/*
+--------------------------------
+@Create 2026/10/18 21:30
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 21:30
+@Description XML元素与属性支持
+--------------------------------
+本文件为各strval类型实现xml.Marshaler、xml.Unmarshaler、xml.MarshalerAttr与xml.UnmarshalerAttr接口，
+使<enabled>Y</enabled>、count="  12 "等写法可以直接解析。元素文本与属性值的解析与UnmarshalJSON中字符串的解析
+共用同一套逻辑，与其他反序列化方法一致，解析失败时置为零值并记录错误日志，日志包含元素或属性名以及所在的行号与列号。
+带有xsi:nil="true"属性的元素解析为零值，NullBool解析为无效值。
+*/
+
+package strval
+
+import (
+	"encoding"
+	"encoding/xml"
+	"log/slog"
+)
+
+// xsiNamespace XML Schema实例命名空间，用于识别xsi:nil属性
+const xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// xmlValue 从XML元素或属性读取的文本与位置
+type xmlValue struct {
+	// name 元素或属性名
+	name string
+	// text 文本内容
+	text string
+	// null 元素带有xsi:nil="true"属性
+	null bool
+	// line 与 column 元素开始标签结束的位置，属性为0
+	line, column int
+}
+
+// readXMLElement 读取元素的文本内容
+func readXMLElement(d *xml.Decoder, start xml.StartElement) (xmlValue, error) {
+	v := xmlValue{name: start.Name.Local}
+	v.line, v.column = d.InputPos()
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "nil" && (attr.Name.Space == xsiNamespace || attr.Name.Space == "xsi") && attr.Value == "true" {
+			v.null = true
+		}
+	}
+	err := d.DecodeElement(&v.text, &start)
+	return v, err
+}
+
+// logXMLError 记录XML值解析失败的错误日志，元素包含所在的行号与列号
+func logXMLError(msg string, v xmlValue, err error) {
+	if v.line == 0 {
+		slog.Error(msg, "attribute", v.name, "value", v.text, "error", err)
+		return
+	}
+	slog.Error(msg, "element", v.name, "value", v.text, "line", v.line, "column", v.column, "error", err)
+}
+
+// encodeXMLText 将TextMarshaler的输出作为元素文本写入
+func encodeXMLText(e *xml.Encoder, start xml.StartElement, m encoding.TextMarshaler) error {
+	text, err := m.MarshalText()
+	if err != nil {
+		return err
+	}
+	return e.EncodeElement(string(text), start)
+}
+
+// encodeXMLAttr 将TextMarshaler的输出作为属性值
+func encodeXMLAttr(name xml.Name, m encoding.TextMarshaler) (xml.Attr, error) {
+	text, err := m.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// MarshalXML 实现xml.Marshaler接口，输出与MarshalText相同的文本
+func (b Bool) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeXMLText(e, start, b)
+}
+
+// UnmarshalXML 实现xml.Unmarshaler接口，元素文本的解析规则与字符串形式的JSON值相同
+// 参数:
+//   - d: XML解码器
+//   - start: 元素的开始标签
+//
+// 返回值:
+//   - error: XML格式错误；值无效时置为false并记录错误日志，不返回错误
+func (b *Bool) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	v, err := readXMLElement(d, start)
+	if err != nil {
+		return err
+	}
+	b.setXML(v)
+	return nil
+}
+
+// MarshalXMLAttr 实现xml.MarshalerAttr接口
+func (b Bool) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return encodeXMLAttr(name, b)
+}
+
+// UnmarshalXMLAttr 实现xml.UnmarshalerAttr接口，属性值的解析规则与字符串形式的JSON值相同
+func (b *Bool) UnmarshalXMLAttr(attr xml.Attr) error {
+	b.setXML(xmlValue{name: attr.Name.Local, text: attr.Value})
+	return nil
+}
+
+// setXML 从XML文本设置值
+func (b *Bool) setXML(v xmlValue) {
+	if v.null {
+		*b = false
+		return
+	}
+	if err := b.setString(v.text, currentOptions()); err != nil {
+		logXMLError("invalid Bool XML value", v, err)
+	}
+}
+
+// MarshalXML 实现xml.Marshaler接口
+func (i Int) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeXMLText(e, start, i)
+}
+
+// UnmarshalXML 实现xml.Unmarshaler接口，元素文本的解析规则与字符串形式的JSON值相同
+// 参数:
+//   - d: XML解码器
+//   - start: 元素的开始标签
+//
+// 返回值:
+//   - error: XML格式错误；值无效时置为0并记录错误日志，不返回错误
+func (i *Int) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	v, err := readXMLElement(d, start)
+	if err != nil {
+		return err
+	}
+	i.setXML(v)
+	return nil
+}
+
+// MarshalXMLAttr 实现xml.MarshalerAttr接口
+func (i Int) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return encodeXMLAttr(name, i)
+}
+
+// UnmarshalXMLAttr 实现xml.UnmarshalerAttr接口，属性值的解析规则与字符串形式的JSON值相同
+func (i *Int) UnmarshalXMLAttr(attr xml.Attr) error {
+	i.setXML(xmlValue{name: attr.Name.Local, text: attr.Value})
+	return nil
+}
+
+// setXML 从XML文本设置值
+func (i *Int) setXML(v xmlValue) {
+	if v.null {
+		*i = 0
+		return
+	}
+	if err := i.setString(v.text, currentOptions()); err != nil {
+		logXMLError("invalid Int XML value", v, err)
+	}
+}
+
+// MarshalXML 实现xml.Marshaler接口
+//
+// 说明：NaN/±Inf的输出规则与MarshalText相同
+func (f Float) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeXMLText(e, start, f)
+}
+
+// UnmarshalXML 实现xml.Unmarshaler接口，元素文本的解析规则与字符串形式的JSON值相同
+// 参数:
+//   - d: XML解码器
+//   - start: 元素的开始标签
+//
+// 返回值:
+//   - error: XML格式错误；值无效时置为0并记录错误日志，不返回错误
+func (f *Float) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	v, err := readXMLElement(d, start)
+	if err != nil {
+		return err
+	}
+	f.setXML(v)
+	return nil
+}
+
+// MarshalXMLAttr 实现xml.MarshalerAttr接口
+func (f Float) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return encodeXMLAttr(name, f)
+}
+
+// UnmarshalXMLAttr 实现xml.UnmarshalerAttr接口，属性值的解析规则与字符串形式的JSON值相同
+func (f *Float) UnmarshalXMLAttr(attr xml.Attr) error {
+	f.setXML(xmlValue{name: attr.Name.Local, text: attr.Value})
+	return nil
+}
+
+// setXML 从XML文本设置值
+func (f *Float) setXML(v xmlValue) {
+	if v.null {
+		*f = 0
+		return
+	}
+	if err := f.setString(v.text, currentOptions()); err != nil {
+		logXMLError("invalid Float XML value", v, err)
+	}
+}
+
+// MarshalXML 实现xml.Marshaler接口
+func (s String) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(string(s), start)
+}
+
+// UnmarshalXML 实现xml.Unmarshaler接口，执行为KindString注册的预处理器
+func (s *String) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	v, err := readXMLElement(d, start)
+	if err != nil {
+		return err
+	}
+	s.setXML(v)
+	return nil
+}
+
+// MarshalXMLAttr 实现xml.MarshalerAttr接口
+func (s String) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: string(s)}, nil
+}
+
+// UnmarshalXMLAttr 实现xml.UnmarshalerAttr接口，执行为KindString注册的预处理器
+func (s *String) UnmarshalXMLAttr(attr xml.Attr) error {
+	s.setXML(xmlValue{name: attr.Name.Local, text: attr.Value})
+	return nil
+}
+
+// setXML 从XML文本设置值
+func (s *String) setXML(v xmlValue) {
+	if v.null {
+		*s = ""
+		return
+	}
+	if err := s.setString(v.text, currentOptions()); err != nil {
+		logXMLError("invalid String XML value", v, err)
+	}
+}
+
+// MarshalXML 实现xml.Marshaler接口，无效值输出空元素
+func (n NullBool) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeXMLText(e, start, n)
+}
+
+// UnmarshalXML 实现xml.Unmarshaler接口，空元素、xsi:nil与未知值词汇得到无效值
+// 参数:
+//   - d: XML解码器
+//   - start: 元素的开始标签
+//
+// 返回值:
+//   - error: XML格式错误；值无效时置为无效值并记录错误日志，不返回错误
+func (n *NullBool) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	v, err := readXMLElement(d, start)
+	if err != nil {
+		return err
+	}
+	n.setXML(v)
+	return nil
+}
+
+// MarshalXMLAttr 实现xml.MarshalerAttr接口，无效值不输出属性
+func (n NullBool) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !n.Valid {
+		return xml.Attr{}, nil
+	}
+	return encodeXMLAttr(name, n)
+}
+
+// UnmarshalXMLAttr 实现xml.UnmarshalerAttr接口，空值与未知值词汇得到无效值
+func (n *NullBool) UnmarshalXMLAttr(attr xml.Attr) error {
+	n.setXML(xmlValue{name: attr.Name.Local, text: attr.Value})
+	return nil
+}
+
+// setXML 从XML文本设置值
+func (n *NullBool) setXML(v xmlValue) {
+	if v.null {
+		*n = NullBool{}
+		return
+	}
+	if err := n.setString(v.text, currentOptions()); err != nil {
+		logXMLError("invalid NullBool XML value", v, err)
+	}
+}
+
+// MarshalXML 实现xml.Marshaler接口，输出包装值
+func (p Preserved[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return any(p.Val).(xml.Marshaler).MarshalXML(e, start)
+}
+
+// UnmarshalXML 实现xml.Unmarshaler接口
+//
+// 说明：XML不记录原始表示，Modified始终返回true
+func (p *Preserved[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*p = Preserved[T]{}
+	return any(&p.Val).(xml.Unmarshaler).UnmarshalXML(d, start)
+}
+
+// MarshalXMLAttr 实现xml.MarshalerAttr接口，输出包装值
+func (p Preserved[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return any(p.Val).(xml.MarshalerAttr).MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr 实现xml.UnmarshalerAttr接口
+func (p *Preserved[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	*p = Preserved[T]{}
+	return any(&p.Val).(xml.UnmarshalerAttr).UnmarshalXMLAttr(attr)
+}
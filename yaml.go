@@ -0,0 +1,122 @@
+//go:build !strval_noyaml
+
+/*
+--------------------------------
+@Create 2026/10/18 18:20
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/18 18:20
+@Description YAML标签处理与YAML 1.2严格模式
+--------------------------------
+本文件实现了各类型UnmarshalYAML共用的标签解析逻辑，识别出的标量交给各类型共用的coerce方法转换：
+1. 显式或隐式的!!null、!!bool、!!int、!!float标签按YAML的规则解析，!!str及其余标量按字符串规则解析
+2. 默认与yaml.v3解码到bool、int等原生类型的结果一致：不带引号的y/yes/on等YAML 1.1布尔词解析为布尔值，
+   前导零的整数按八进制解析，如0755为493
+3. 启用Options.YAML12后按YAML 1.2核心模式解析，0755为十进制数，on/off为字符串并按布尔值词汇表解析
+
+与yamltypes.go、yamlcodec.go一样，本文件在构建标签strval_noyaml下不参与编译。
+*/
+
+package strval
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlNode Preserved记录的原始YAML节点类型
+type yamlNode = yaml.Node
+
+var (
+	// yamlCoreTrue 与 yamlCoreFalse YAML 1.2核心模式的布尔值
+	yamlCoreTrue  = []string{"true", "True", "TRUE"}
+	yamlCoreFalse = []string{"false", "False", "FALSE"}
+
+	// yaml11True 与 yaml11False YAML 1.1额外支持的布尔值
+	yaml11True  = []string{"y", "Y", "yes", "Yes", "YES", "on", "On", "ON"}
+	yaml11False = []string{"n", "N", "no", "No", "NO", "off", "Off", "OFF"}
+)
+
+// yamlTag 返回节点生效的标签
+//
+// 说明：未启用YAML 1.2严格模式时，不带引号且没有显式标签的YAML 1.1布尔词视为!!bool，与yaml.v3解码到bool时一致
+func yamlTag(node *yaml.Node, o *Options) string {
+	tag := node.ShortTag()
+	if !o.YAML12 && tag == "!!str" && node.Kind == yaml.ScalarNode && node.Style == 0 &&
+		(slices.Contains(yaml11True, node.Value) || slices.Contains(yaml11False, node.Value)) {
+		return "!!bool"
+	}
+	return tag
+}
+
+// parseYAMLBool 按YAML规则解析!!bool标量
+func parseYAMLBool(s string, o *Options) (bool, error) {
+	switch {
+	case slices.Contains(yamlCoreTrue, s), !o.YAML12 && slices.Contains(yaml11True, s):
+		return true, nil
+	case slices.Contains(yamlCoreFalse, s), !o.YAML12 && slices.Contains(yaml11False, s):
+		return false, nil
+	}
+	return false, fmt.Errorf("cannot parse '%s' as !!bool", s)
+}
+
+// parseYAMLInt 按YAML规则解析!!int标量
+//
+// 说明：始终允许进制前缀与下划线分隔符；前导零的写法默认按八进制解析，与yaml.v3一致，YAML 1.2严格模式下为十进制
+func parseYAMLInt(s string, bitSize int, o *Options) (int64, error) {
+	yo := *o
+	yo.IntSyntax |= IntBasePrefix | IntUnderscore
+	if !o.YAML12 {
+		yo.IntSyntax |= IntLegacyOctal
+	} else {
+		yo.IntSyntax &^= IntLegacyOctal
+	}
+	return parseInt(s, bitSize, &yo)
+}
+
+// yamlScalar 将YAML节点识别为标量，供各类型的unmarshalYAML使用
+//
+// 说明：标量节点总是保留原始文本；标签与文本不符（如YAML 1.2严格模式下的!!bool yes）时为无效值，
+// 超出int64范围的!!int按浮点数处理
+func yamlScalar(node *yaml.Node, o *Options) scalar {
+	if node.Kind != yaml.ScalarNode {
+		return invalidScalar(fmt.Errorf("cannot unmarshal %s into a scalar", node.ShortTag()))
+	}
+	v := scalar{text: node.Value, hasText: true}
+	var err error
+	switch yamlTag(node, o) {
+	case "!!null":
+		return scalar{kind: scalarNull}
+	case "!!bool":
+		// 显式或隐式的!!bool按YAML规则解析，不经过词汇表
+		v.kind = scalarBool
+		v.b, err = parseYAMLBool(node.Value, o)
+	case "!!int":
+		v.kind = scalarInt
+		if v.i, err = parseYAMLInt(node.Value, 64, o); errors.Is(err, strconv.ErrRange) {
+			if f, ferr := strconv.ParseFloat(node.Value, 64); ferr == nil {
+				v.kind, v.f, err = scalarFloat, f, nil
+			}
+		}
+	case "!!float":
+		v.kind = scalarFloat
+		err = node.Decode(&v.f)
+	case "!!binary":
+		// !!binary按base64解码
+		var decoded string
+		if err = node.Decode(&decoded); err != nil {
+			return invalidScalar(err)
+		}
+		return textScalar(decoded, false)
+	default:
+		return textScalar(node.Value, false)
+	}
+	if err != nil {
+		v.kind, v.err = scalarInvalid, err
+	}
+	return v
+}
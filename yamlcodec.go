@@ -0,0 +1,320 @@
+//go:build !strval_noyaml
+
+/*
+--------------------------------
+@Create 2026/10/19 02:10
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 02:10
+@Description 按调用指定选项的YAML编解码
+--------------------------------
+DecodeYAML与EncodeYAML原先与JSON版本一起位于decode.go与encode.go，为了让不使用YAML的程序不再依赖yaml.v3，
+现单独放在本文件中，使用构建标签strval_noyaml编译时不包含。遍历规则与DecodeJSON/EncodeJSON相同。
+*/
+
+package strval
+
+import (
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	yamlUnmarshalerType = reflect.TypeFor[yaml.Unmarshaler]()
+	yamlMarshalerType   = reflect.TypeFor[yaml.Marshaler]()
+	yamlIsZeroerType    = reflect.TypeFor[yaml.IsZeroer]()
+)
+
+// DecodeYAML 使用指定选项将YAML数据反序列化到v
+// 参数:
+//   - data: YAML数据字节
+//   - v: 目标值指针
+//   - opts: 覆盖全局选项的单次调用选项
+//
+// 返回值:
+//   - error: YAML结构错误、strval标签错误或非strval字段的反序列化错误；strval字段的解析失败与UnmarshalYAML一致只记录日志
+func DecodeYAML(data []byte, v any, opts ...Option) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		// 交给yaml.v3生成标准的错误
+		return yaml.Unmarshal(data, v)
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	return decodeYAMLNode(&node, rv.Elem(), resolveOptions(opts))
+}
+
+// decodeYAMLNode 将YAML节点反序列化到可寻址的值
+func decodeYAMLNode(node *yaml.Node, v reflect.Value, o *Options) error {
+	switch node.Kind {
+	case 0:
+		// 空文档
+		return nil
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil
+		}
+		return decodeYAMLNode(node.Content[0], v, o)
+	case yaml.AliasNode:
+		return decodeYAMLNode(node.Alias, v, o)
+	}
+
+	if !containsStrval(v.Type()) {
+		return node.Decode(v.Addr().Interface())
+	}
+	if u, ok := v.Addr().Interface().(optionYAMLUnmarshaler); ok {
+		u.unmarshalYAML(node, o)
+		return nil
+	}
+	if v.Addr().Type().Implements(yamlUnmarshalerType) {
+		return node.Decode(v.Addr().Interface())
+	}
+
+	null := node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null"
+	switch v.Kind() {
+	case reflect.Pointer:
+		if null {
+			v.SetZero()
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeYAMLNode(node, v.Elem(), o)
+
+	case reflect.Struct:
+		if null {
+			return nil
+		}
+		if node.Kind != yaml.MappingNode {
+			return node.Decode(v.Addr().Interface())
+		}
+		fields := cachedFields(v.Type(), "yaml")
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.ShortTag() == "!!merge" {
+				if err := decodeYAMLMerge(value, v, o); err != nil {
+					return err
+				}
+				continue
+			}
+			f := lookupField(fields, key.Value, false)
+			if f == nil {
+				continue
+			}
+			fo, err := fieldOptions(o, f.tag)
+			if err != nil {
+				return err
+			}
+			if err := decodeYAMLNode(value, fieldByIndex(v, f.index), fo.at(f.name)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Slice, reflect.Array:
+		if null {
+			if v.Kind() == reflect.Slice {
+				v.SetZero()
+			}
+			return nil
+		}
+		if node.Kind != yaml.SequenceNode {
+			return node.Decode(v.Addr().Interface())
+		}
+		target := v
+		if v.Kind() == reflect.Slice {
+			target = reflect.MakeSlice(v.Type(), len(node.Content), len(node.Content))
+		}
+		for i := 0; i < target.Len(); i++ {
+			if i >= len(node.Content) {
+				target.Index(i).SetZero()
+				continue
+			}
+			if err := decodeYAMLNode(node.Content[i], target.Index(i), o.at(indexPath(i))); err != nil {
+				return err
+			}
+		}
+		if v.Kind() == reflect.Slice {
+			v.Set(target)
+		}
+		return nil
+
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String || node.Kind != yaml.MappingNode {
+			if null {
+				v.SetZero()
+				return nil
+			}
+			return node.Decode(v.Addr().Interface())
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(v.Type(), len(node.Content)/2))
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeYAMLNode(node.Content[i+1], elem, o.at(node.Content[i].Value)); err != nil {
+				return err
+			}
+			v.SetMapIndex(reflect.ValueOf(node.Content[i].Value).Convert(v.Type().Key()), elem)
+		}
+		return nil
+	}
+	return node.Decode(v.Addr().Interface())
+}
+
+// decodeYAMLMerge 处理YAML合并键"<<"，值可以是映射或映射的序列
+func decodeYAMLMerge(node *yaml.Node, v reflect.Value, o *Options) error {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == yaml.SequenceNode {
+		// 序列中靠前的映射优先，因此倒序应用
+		for i := len(node.Content) - 1; i >= 0; i-- {
+			if err := decodeYAMLNode(node.Content[i], v, o); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return decodeYAMLNode(node, v, o)
+}
+
+// EncodeYAML 使用指定选项将v序列化为YAML
+// 参数:
+//   - v: 需要序列化的值
+//   - opts: 覆盖全局选项的单次调用选项
+//
+// 返回值:
+//   - []byte: 序列化后的YAML字节
+//   - error: 序列化过程中的错误或strval标签错误
+func EncodeYAML(v any, opts ...Option) ([]byte, error) {
+	node, err := encodeYAMLNode(reflect.ValueOf(v), resolveOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(node)
+}
+
+// encodeYAMLNode 将值序列化为YAML节点
+func encodeYAMLNode(v reflect.Value, o *Options) (*yaml.Node, error) {
+	if !v.IsValid() {
+		return yamlNull(), nil
+	}
+	// nil指针与接口在类型断言之前处理，值接收者的方法不能通过nil指针调用
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		return yamlNull(), nil
+	}
+	if v.Kind() == reflect.Interface {
+		return encodeYAMLNode(v.Elem(), o)
+	}
+	if m, ok := v.Interface().(optionYAMLMarshaler); ok {
+		out, err := m.marshalYAML(o)
+		if err != nil {
+			return nil, err
+		}
+		if node, ok := out.(*yaml.Node); ok {
+			return node, nil
+		}
+		return encodeYAMLAny(out)
+	}
+	if !containsStrval(v.Type()) || v.Type().Implements(yamlMarshalerType) {
+		return encodeYAMLAny(v.Interface())
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		return encodeYAMLNode(v.Elem(), o)
+
+	case reflect.Struct:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, f := range cachedFields(v.Type(), "yaml") {
+			fv, ok := fieldValue(v, f.index)
+			if !ok || (f.hasOpt("omitempty") && isZeroYAML(fv)) {
+				continue
+			}
+			fo, err := fieldOptions(o, f.tag)
+			if err != nil {
+				return nil, err
+			}
+			value, err := encodeYAMLNode(fv, fo)
+			if err != nil {
+				return nil, err
+			}
+			if f.hasOpt("flow") {
+				// 节点可能是Preserved保存的原始节点，复制后再修改样式
+				flow := *value
+				flow.Style |= yaml.FlowStyle
+				value = &flow
+			}
+			node.Content = append(node.Content, yamlKey(f.name), value)
+		}
+		return node, nil
+
+	case reflect.Slice, reflect.Array:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for i := 0; i < v.Len(); i++ {
+			item, err := encodeYAMLNode(v.Index(i), o)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, item)
+		}
+		return node, nil
+
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return encodeYAMLAny(v.Interface())
+		}
+		if v.IsNil() {
+			return yamlNull(), nil
+		}
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, key := range sortedMapKeys(v) {
+			value, err := encodeYAMLNode(v.MapIndex(key), o)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, yamlKey(key.String()), value)
+		}
+		return node, nil
+	}
+	return encodeYAMLAny(v.Interface())
+}
+
+// encodeYAMLAny 使用yaml.v3将任意值序列化为节点
+func encodeYAMLAny(v any) (*yaml.Node, error) {
+	node := new(yaml.Node)
+	if err := node.Encode(v); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// yamlNull 返回表示null的节点
+func yamlNull() *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+}
+
+// yamlKey 返回映射键节点
+func yamlKey(name string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}
+}
+
+// isZeroYAML 判断值是否为零值，规则与yaml.v3的omitempty一致
+func isZeroYAML(v reflect.Value) bool {
+	if v.Type().Implements(yamlIsZeroerType) {
+		if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+			return true
+		}
+		return v.Interface().(yaml.IsZeroer).IsZero()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
@@ -0,0 +1,318 @@
+//go:build !strval_noyaml
+
+/*
+--------------------------------
+@Create 2026/10/19 02:10
+@Author lengpucheng<lpc@hll520.cn>
+@Project go-strval
+@Version 1.0.0 2026/10/19 02:10
+@Description 各类型的yaml.Marshaler与yaml.Unmarshaler实现
+--------------------------------
+本文件为各strval类型与Preserved实现yaml.Marshaler与yaml.Unmarshaler接口。
+这些方法原先与JSON、数据库的实现一起位于strval.go与preserve.go，因参数类型为*yaml.Node，
+导入strval的程序总会依赖yaml.v3。现单独放在本文件中，使用构建标签strval_noyaml编译时不包含，
+只使用JSON与数据库的小型服务或WASM程序因此不再链接yaml.v3；默认构建的行为不变。
+*/
+
+package strval
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// optionYAMLUnmarshaler 由各strval类型实现，支持按指定选项从YAML反序列化
+type optionYAMLUnmarshaler interface {
+	unmarshalYAML(node *yaml.Node, o *Options)
+}
+
+// optionYAMLMarshaler 由各strval类型实现，支持按指定选项序列化为YAML
+type optionYAMLMarshaler interface {
+	marshalYAML(o *Options) (interface{}, error)
+}
+
+// MarshalYAML 实现yaml.Marshaler接口，将Bool序列化为YAML布尔值
+// 返回值:
+//   - interface{}: 序列化后的值
+//   - error: 序列化过程中的错误
+func (b Bool) MarshalYAML() (interface{}, error) {
+	return b.marshalYAML(currentOptions())
+}
+
+// marshalYAML 按指定选项序列化为YAML，供MarshalYAML与EncodeYAML共用
+func (b Bool) marshalYAML(o *Options) (interface{}, error) {
+	return o.boolYAML(bool(b)), nil
+}
+
+// UnmarshalYAML 实现yaml.Unmarshaler接口，支持从YAML布尔值或字符串反序列化为Bool
+// 参数:
+//   - node: YAML节点
+//
+// 返回值:
+//   - error: 反序列化过程中的错误
+//
+// 说明:
+//   - 显式或隐式的!!bool按YAML规则解析，默认包括yes/on等YAML 1.1布尔词，全局选项YAML12启用时不包括
+//   - !!int只接受0与1
+//   - !!str及其余标量按字符串形式的布尔值解析
+//   - 解析失败时返回false并记录包含行列号的错误日志
+func (b *Bool) UnmarshalYAML(node *yaml.Node) error {
+	b.unmarshalYAML(node, currentOptions())
+	return nil
+}
+
+// unmarshalYAML 按指定选项从YAML节点反序列化，供UnmarshalYAML与DecodeYAML共用
+func (b *Bool) unmarshalYAML(node *yaml.Node, o *Options) {
+	if err := b.coerce(yamlScalar(node, o), o); err != nil {
+		slog.Error("invalid Bool value", "error", err, "line", node.Line, "column", node.Column)
+	}
+}
+
+// MarshalYAML 实现yaml.Marshaler接口，将Int序列化为YAML数值
+// 返回值:
+//   - interface{}: 序列化后的值
+//   - error: 序列化过程中的错误
+func (i Int) MarshalYAML() (interface{}, error) {
+	return i.marshalYAML(currentOptions())
+}
+
+// marshalYAML 按指定选项序列化为YAML，供MarshalYAML与EncodeYAML共用
+func (i Int) marshalYAML(o *Options) (interface{}, error) {
+	if o.NumberOutput == NumberOutputString {
+		return strconv.Itoa(int(i)), nil
+	}
+	return int(i), nil
+}
+
+// UnmarshalYAML 实现yaml.Unmarshaler接口，支持从YAML数值或字符串反序列化为Int
+// 参数:
+//   - node: YAML节点
+//
+// 返回值:
+//   - error: 反序列化过程中的错误
+//
+// 说明:
+//   - !!int按YAML规则解析，前导零的写法默认按八进制解析，全局选项YAML12启用时按十进制解析
+//   - !!float只接受小数部分为零的浮点数
+//   - !!str及其余标量按字符串形式的整数值解析，扩展语法由全局选项IntSyntax控制
+//   - 解析失败时返回0并记录包含行列号的错误日志
+func (i *Int) UnmarshalYAML(node *yaml.Node) error {
+	i.unmarshalYAML(node, currentOptions())
+	return nil
+}
+
+// unmarshalYAML 按指定选项从YAML节点反序列化，供UnmarshalYAML与DecodeYAML共用
+func (i *Int) unmarshalYAML(node *yaml.Node, o *Options) {
+	if err := i.coerce(yamlScalar(node, o), o); err != nil {
+		slog.Error("invalid Int value", "error", err, "line", node.Line, "column", node.Column)
+	}
+}
+
+// MarshalYAML 实现yaml.Marshaler接口，将Float序列化为YAML数值
+// 返回值:
+//   - interface{}: 序列化后的值
+//   - error: 序列化过程中的错误
+//
+// 说明:
+//   - NaN/±Inf按全局选项NonFiniteOutput处理，默认输出YAML原生的.nan/.inf
+//   - 数字格式与输出形式由全局选项FloatFormat与NumberOutput控制
+func (f Float) MarshalYAML() (interface{}, error) {
+	return f.marshalYAML(currentOptions())
+}
+
+// marshalYAML 按指定选项序列化为YAML，供MarshalYAML与EncodeYAML共用
+func (f Float) marshalYAML(o *Options) (interface{}, error) {
+	v := float64(f)
+	if isNonFinite(v) {
+		switch o.NonFiniteOutput {
+		case NonFiniteOutputNull:
+			return nil, nil
+		case NonFiniteOutputString:
+			return nonFiniteString(v), nil
+		case NonFiniteOutputError:
+			return nil, fmt.Errorf("strval: unsupported Float value %s", nonFiniteString(v))
+		}
+		return v, nil
+	}
+	switch {
+	case o.NumberOutput == NumberOutputString:
+		return o.floatText(v), nil
+	case o.FloatFormat.Fixed:
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: o.floatText(v)}, nil
+	}
+	return v, nil
+}
+
+// UnmarshalYAML 实现yaml.Unmarshaler接口，支持从YAML数值或字符串反序列化为Float
+// 参数:
+//   - node: YAML节点
+//
+// 返回值:
+//   - error: 反序列化过程中的错误
+//
+// 说明:
+//   - !!int与!!float按YAML规则解析
+//   - !!str及其余标量按字符串形式的浮点数值解析
+//   - 解析失败时返回0并记录包含行列号的错误日志
+func (f *Float) UnmarshalYAML(node *yaml.Node) error {
+	f.unmarshalYAML(node, currentOptions())
+	return nil
+}
+
+// unmarshalYAML 按指定选项从YAML节点反序列化，供UnmarshalYAML与DecodeYAML共用
+func (f *Float) unmarshalYAML(node *yaml.Node, o *Options) {
+	if err := f.coerce(yamlScalar(node, o), o); err != nil {
+		slog.Error("invalid Float value", "error", err, "line", node.Line, "column", node.Column)
+	}
+}
+
+// MarshalYAML 实现yaml.Marshaler接口，将String序列化为YAML字符串
+// 返回值:
+//   - interface{}: 序列化后的值
+//   - error: 序列化过程中的错误
+func (s String) MarshalYAML() (interface{}, error) {
+	return s.marshalYAML(currentOptions())
+}
+
+// marshalYAML 按指定选项序列化为YAML，供MarshalYAML与EncodeYAML共用，输出形式不受选项影响
+func (s String) marshalYAML(_ *Options) (interface{}, error) {
+	return string(s), nil
+}
+
+// UnmarshalYAML 实现yaml.Unmarshaler接口，支持从YAML字符串或其他类型反序列化为String
+// 参数:
+//   - node: YAML节点
+//
+// 返回值:
+//   - error: 反序列化过程中的错误
+func (s *String) UnmarshalYAML(node *yaml.Node) error {
+	s.unmarshalYAML(node, currentOptions())
+	return nil
+}
+
+// unmarshalYAML 按指定选项从YAML节点反序列化，供UnmarshalYAML与DecodeYAML共用
+func (s *String) unmarshalYAML(node *yaml.Node, o *Options) {
+	if err := s.coerce(yamlScalar(node, o), o); err != nil {
+		slog.Error("invalid String value", "error", err, "line", node.Line, "column", node.Column)
+	}
+}
+
+// MarshalYAML 实现yaml.Marshaler接口，将NullBool序列化为YAML布尔值，无效值序列化为null
+// 返回值:
+//   - interface{}: 序列化后的值
+//   - error: 序列化过程中的错误
+func (n NullBool) MarshalYAML() (interface{}, error) {
+	return n.marshalYAML(currentOptions())
+}
+
+// marshalYAML 按指定选项序列化为YAML，供MarshalYAML与EncodeYAML共用
+func (n NullBool) marshalYAML(o *Options) (interface{}, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return o.boolYAML(n.Bool), nil
+}
+
+// UnmarshalYAML 实现yaml.Unmarshaler接口，支持从YAML布尔值、字符串或null反序列化为NullBool
+// 参数:
+//   - node: YAML节点
+//
+// 返回值:
+//   - error: 反序列化过程中的错误
+func (n *NullBool) UnmarshalYAML(node *yaml.Node) error {
+	n.unmarshalYAML(node, currentOptions())
+	return nil
+}
+
+// unmarshalYAML 按指定选项从YAML节点反序列化，供UnmarshalYAML与DecodeYAML共用
+func (n *NullBool) unmarshalYAML(node *yaml.Node, o *Options) {
+	if err := n.coerce(yamlScalar(node, o), o); err != nil {
+		slog.Error("invalid NullBool value", "error", err, "line", node.Line, "column", node.Column)
+	}
+}
+
+// MarshalYAML 实现yaml.Marshaler接口，值未修改时输出原始节点，保留引号风格、标签与注释
+// 返回值:
+//   - interface{}: 序列化后的值
+//   - error: 序列化过程中的错误
+//
+// 说明：值被修改时沿用原始节点的风格与注释；原始节点带引号时新值同样以字符串形式输出
+func (p Preserved[T]) MarshalYAML() (interface{}, error) {
+	return p.marshalYAML(currentOptions())
+}
+
+// marshalYAML 按指定选项序列化为YAML，供MarshalYAML与EncodeYAML共用
+func (p Preserved[T]) marshalYAML(o *Options) (interface{}, error) {
+	out, err := any(p.Val).(optionYAMLMarshaler).marshalYAML(o)
+	if err != nil || p.node == nil {
+		return out, err
+	}
+	if !p.Modified() {
+		return p.node, nil
+	}
+
+	value, ok := out.(*yaml.Node)
+	if !ok {
+		value = new(yaml.Node)
+		if err := value.Encode(out); err != nil {
+			return nil, err
+		}
+	}
+	if value.Kind != yaml.ScalarNode {
+		return value, nil
+	}
+	node := *p.node
+	node.Value = value.Value
+	if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) == 0 || value.ShortTag() == "!!null" {
+		node.Tag, node.Style = value.Tag, value.Style
+	} else {
+		node.Tag = "!!str"
+	}
+	return &node, nil
+}
+
+// UnmarshalYAML 实现yaml.Unmarshaler接口，解析规则与T相同，同时记录原始节点
+// 参数:
+//   - node: YAML节点
+//
+// 返回值:
+//   - error: 反序列化过程中的错误
+func (p *Preserved[T]) UnmarshalYAML(node *yaml.Node) error {
+	p.unmarshalYAML(node, currentOptions())
+	return nil
+}
+
+// unmarshalYAML 按指定选项从YAML节点反序列化，供UnmarshalYAML与DecodeYAML共用
+func (p *Preserved[T]) unmarshalYAML(node *yaml.Node, o *Options) {
+	any(&p.Val).(optionYAMLUnmarshaler).unmarshalYAML(node, o)
+	p.orig = p.Val
+	p.raw = nil
+	if node.Kind == yaml.ScalarNode {
+		n := *node
+		p.node = &n
+	} else {
+		p.node = nil
+	}
+}
+
+// boolYAML 按输出形式将布尔值序列化为YAML
+//
+// 说明：yes/no、Y/N以不带引号的字符串输出，与YAML 1.1的写法一致；"true"/"false"带引号输出
+func (o *Options) boolYAML(b bool) interface{} {
+	switch o.BoolOutput {
+	case BoolOutputNative:
+		return b
+	case BoolOutputNumber:
+		if b {
+			return 1
+		}
+		return 0
+	case BoolOutputString:
+		return o.boolText(b)
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: o.boolText(b)}
+	}
+}